@@ -29,6 +29,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/metrics"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/serialize"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatetoken"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
@@ -38,6 +39,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/timeout"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	metricstools "github.com/networkservicemesh/sdk/pkg/tools/metrics"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
@@ -110,6 +112,8 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 			// chain elements before the `timeout` in chain shouldn't make any updates to the Close context and
 			// shouldn't be closed on Connection Close.
 			timeout.NewServer(ctx),
+			// `metrics` should be after the `timeout` to count expired connections as closed.
+			metrics.NewServer(opts.name),
 			metadata.NewServer(),
 			monitor.NewServer(ctx, &rv.MonitorConnectionServer),
 			updatetoken.NewServer(tokenGenerator),
@@ -130,3 +134,8 @@ func Serve(ctx context.Context, listenOn *url.URL, endpoint Endpoint, opt ...grp
 
 	return grpcutils.ListenAndServe(ctx, listenOn, server)
 }
+
+// ServeMetrics - serves metrics from the metrics.Registry() over HTTP on the listenOn tcp address
+func ServeMetrics(ctx context.Context, listenOn *url.URL) <-chan error {
+	return metricstools.ListenAndServe(ctx, listenOn, metricstools.Registry())
+}
@@ -41,12 +41,16 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/interpose"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/recvfd"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/sendfd"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/selectendpoint"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry"
+	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/localbypass"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	registrymetrics "github.com/networkservicemesh/sdk/pkg/registry/common/metrics"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/querycache"
 	registryrecvfd "github.com/networkservicemesh/sdk/pkg/registry/common/recvfd"
 	registryserialize "github.com/networkservicemesh/sdk/pkg/registry/common/serialize"
@@ -73,11 +77,15 @@ type nsmgrServer struct {
 }
 
 type serverOptions struct {
-	authorizeServer networkservice.NetworkServiceServer
-	dialOptions     []grpc.DialOption
-	regClientConn   *grpc.ClientConnInterface
-	name            string
-	url             string
+	authorizeServer            networkservice.NetworkServiceServer
+	admissionServer            networkservice.NetworkServiceServer
+	authorizeNSRegistryServer  registryapi.NetworkServiceRegistryServer
+	authorizeNSERegistryServer registryapi.NetworkServiceEndpointRegistryServer
+	selector                   selectendpoint.Selector
+	dialOptions                []grpc.DialOption
+	regClientConn              *grpc.ClientConnInterface
+	name                       string
+	url                        string
 }
 
 // Option modifies server option value
@@ -100,6 +108,48 @@ func WithAuthorizeServer(authorizeServer networkservice.NetworkServiceServer) Op
 	}
 }
 
+// WithAdmissionServer sets admission control server chain element, it is placed after the `timeout` so expired
+// connections are closed through it. Requests returned to the Nsmgr by the interposed forwarder are not admitted
+// again, only the client ones are limited. Default is no admission control.
+func WithAdmissionServer(admissionServer networkservice.NetworkServiceServer) Option {
+	if admissionServer == nil {
+		panic("Admission server cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.admissionServer = admissionServer
+	}
+}
+
+// WithAuthorizeNSRegistryServer sets authorization NetworkServiceRegistry chain element
+func WithAuthorizeNSRegistryServer(authorizeNSRegistryServer registryapi.NetworkServiceRegistryServer) Option {
+	if authorizeNSRegistryServer == nil {
+		panic("Authorize NS registry server cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSRegistryServer = authorizeNSRegistryServer
+	}
+}
+
+// WithAuthorizeNSERegistryServer sets authorization NetworkServiceEndpointRegistry chain element
+func WithAuthorizeNSERegistryServer(authorizeNSERegistryServer registryapi.NetworkServiceEndpointRegistryServer) Option {
+	if authorizeNSERegistryServer == nil {
+		panic("Authorize NSE registry server cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSERegistryServer = authorizeNSERegistryServer
+	}
+}
+
+// WithEndpointSelector sets endpoint selection strategy, default is round robin
+func WithEndpointSelector(selector selectendpoint.Selector) Option {
+	if selector == nil {
+		panic("Endpoint selector cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.selector = selector
+	}
+}
+
 // WithRegistryClientConn sets client connection to reach the upstream registry, if not passed memory storage will be used.
 // Please do not pass nil value of registry connection.
 func WithRegistryClientConn(regClientConn grpc.ClientConnInterface) Option {
@@ -130,9 +180,13 @@ var _ Nsmgr = (*nsmgrServer)(nil)
 //			 options - a set of Nsmgr options.
 func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...Option) Nsmgr {
 	opts := &serverOptions{
-		authorizeServer: authorize.NewServer(authorize.Any()),
-		name:            "nsmgr-" + uuid.New().String(),
-		url:             "",
+		authorizeServer:            authorize.NewServer(authorize.Any()),
+		admissionServer:            null.NewServer(),
+		authorizeNSRegistryServer:  registryauthorize.NewNetworkServiceRegistryServer(registryauthorize.Any()),
+		authorizeNSERegistryServer: registryauthorize.NewNetworkServiceEndpointRegistryServer(registryauthorize.Any()),
+		selector:                   roundrobin.NewSelector(),
+		name:                       "nsmgr-" + uuid.New().String(),
+		url:                        "",
 	}
 	for _, opt := range options {
 		opt(opts)
@@ -180,15 +234,20 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 		registryadapter.NetworkServiceEndpointServerToClient(nseRegistry),
 	)
 
-	nsClient := registryadapter.NetworkServiceServerToClient(nsRegistry)
+	nsCacheClient := querycache.NewNetworkServiceRegistryClient(ctx)
+	nsClient := next.NewNetworkServiceRegistryClient(
+		nsCacheClient,
+		registryadapter.NetworkServiceServerToClient(nsRegistry),
+	)
 
 	// Construct Endpoint
 	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator,
 		endpoint.WithName(opts.name),
 		endpoint.WithAuthorizeServer(opts.authorizeServer),
 		endpoint.WithAdditionalFunctionality(
+			opts.admissionServer,
 			discover.NewServer(nsClient, nseClient),
-			roundrobin.NewServer(),
+			selectendpoint.NewServer(opts.selector),
 			excludedprefixes.NewServer(ctx),
 			recvfd.NewServer(), // Receive any files passed
 			interpose.NewServer(&interposeRegistryServer),
@@ -206,12 +265,20 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 			sendfd.NewServer()),
 	)
 
-	nsChain := registrychain.NewNamedNetworkServiceRegistryServer(opts.name+".NetworkServiceRegistry", nsRegistry)
+	nsChain := registrychain.NewNamedNetworkServiceRegistryServer(
+		opts.name+".NetworkServiceRegistry",
+		opts.authorizeNSRegistryServer,
+		registrymetrics.NewNetworkServiceRegistryServer(opts.name),
+		querycache.NewNetworkServiceRegistryServer(nsCacheClient), // Drop unregistered NSs from the discover cache
+		nsRegistry,
+	)
 
 	nseChain := registrychain.NewNamedNetworkServiceEndpointRegistryServer(
 		opts.name+".NetworkServiceEndpointRegistry",
+		opts.authorizeNSERegistryServer,
 		registryserialize.NewNetworkServiceEndpointRegistryServer(),
 		expire.NewNetworkServiceEndpointRegistryServer(ctx, time.Minute),
+		registrymetrics.NewNetworkServiceEndpointRegistryServer(opts.name),
 		registryrecvfd.NewNetworkServiceEndpointRegistryServer(), // Allow to receive a passed files
 		urlsRegistryServer,        // Store endpoints URLs
 		interposeRegistryServer,   // Store cross connect NSEs
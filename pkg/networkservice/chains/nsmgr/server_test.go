@@ -20,7 +20,10 @@ package nsmgr_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -41,6 +44,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/admission"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/kernel"
@@ -48,6 +53,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
 	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
 func TestNSMGR_RemoteUsecase_Parallel(t *testing.T) {
@@ -295,6 +301,57 @@ func TestNSMGR_RemoteUsecase(t *testing.T) {
 	require.Equal(t, int32(1), atomic.LoadInt32(&counter.Closes))
 }
 
+func TestNSMGR_RemoteUsecase_RegistryReplicas(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(t).
+		SetNodesCount(2).
+		SetRegistryReplicasCount(2).
+		SetRegistryProxySupplier(nil).
+		SetContext(ctx).
+		Build()
+
+	require.Len(t, domain.RegistryReplicas, 2)
+
+	nseReg := &registry.NetworkServiceEndpoint{
+		Name:                "final-endpoint",
+		NetworkServiceNames: []string{"my-service-remote"},
+	}
+
+	// Endpoint is registered with the first registry replica
+	counter := &counterServer{}
+	_, err := domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken, counter)
+	require.NoError(t, err)
+
+	request := &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.LOCAL, Type: kernelmech.MECHANISM},
+		},
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: "my-service-remote",
+			Context:        &networkservice.ConnectionContext{},
+		},
+	}
+
+	// Client discovers it with the second registry replica
+	nsc := domain.Nodes[1].NewClient(ctx, sandbox.GenerateTestToken)
+
+	conn, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	require.Equal(t, int32(1), atomic.LoadInt32(&counter.Requests))
+	require.Equal(t, 8, len(conn.Path.PathSegments))
+
+	e, err := nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	require.Equal(t, int32(1), atomic.LoadInt32(&counter.Closes))
+}
+
 func TestNSMGR_ConnectToDeadNSE(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -864,3 +921,127 @@ func TestNSMGR_LocalUsecaseNoURL(t *testing.T) {
 	require.NotNil(t, e)
 	require.Equal(t, int32(1), atomic.LoadInt32(&counter.Closes))
 }
+
+func TestNSMGR_Admission(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "admission")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	configPath := filepath.Join(dir, "admission.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+networkService:
+  maxConnections: 1
+`), os.ModePerm))
+
+	domain := sandbox.NewBuilder(t).
+		SetNodesCount(1).
+		SetContext(ctx).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		SetNSMgrSupplier(func(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...nsmgr.Option) nsmgr.Nsmgr {
+			options = append(options, nsmgr.WithAdmissionServer(admission.NewServer(ctx, admission.WithConfigPath(configPath))))
+			return nsmgr.NewServer(ctx, tokenGenerator, options...)
+		}).
+		Build()
+
+	nseReg := &registry.NetworkServiceEndpoint{
+		Name:                "final-endpoint",
+		NetworkServiceNames: []string{"my-service"},
+	}
+
+	_, err = domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken)
+	require.NoError(t, err)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+
+	request := func(id string) *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			MechanismPreferences: []*networkservice.Mechanism{
+				{Cls: cls.LOCAL, Type: kernelmech.MECHANISM},
+			},
+			Connection: &networkservice.Connection{
+				Id:             id,
+				NetworkService: "my-service",
+				Context:        &networkservice.ConnectionContext{},
+			},
+		}
+	}
+
+	// Request returned by the forwarder is not counted as the second connection
+	conn, err := nsc.Request(ctx, request("1"))
+	require.NoError(t, err)
+	require.Equal(t, 5, len(conn.Path.PathSegments))
+
+	refreshRequest := request("1")
+	refreshRequest.Connection = conn.Clone()
+
+	conn, err = nsc.Request(ctx, refreshRequest)
+	require.NoError(t, err)
+
+	_, err = nsc.Request(ctx, request("2"))
+	require.Error(t, err)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+
+	_, err = nsc.Request(ctx, request("2"))
+	require.NoError(t, err)
+}
+
+func TestNSMGR_UnregisterNetworkService(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(t).
+		SetNodesCount(1).
+		SetContext(ctx).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nseReg := &registry.NetworkServiceEndpoint{
+		Name:                "final-endpoint",
+		NetworkServiceNames: []string{"my-service"},
+	}
+
+	_, err := domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken)
+	require.NoError(t, err)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+
+	request := func(id string) *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			MechanismPreferences: []*networkservice.Mechanism{
+				{Cls: cls.LOCAL, Type: kernelmech.MECHANISM},
+			},
+			Connection: &networkservice.Connection{
+				Id:             id,
+				NetworkService: "my-service",
+				Context:        &networkservice.ConnectionContext{},
+			},
+		}
+	}
+
+	// NS is resolved and cached by nsmgr
+	conn, err := nsc.Request(ctx, request("1"))
+	require.NoError(t, err)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+
+	_, err = domain.Nodes[0].NSRegistryClient.Unregister(ctx, &registry.NetworkService{
+		Name: "my-service",
+	})
+	require.NoError(t, err)
+
+	// Unregistered NS is not resolved from the cache
+	requestCtx, cancelRequest := context.WithTimeout(ctx, time.Second)
+	defer cancelRequest()
+
+	_, err = nsc.Request(requestCtx, request("2"))
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"math"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// Limits - admission limits for a single client or network service, zero value means unlimited
+type Limits struct {
+	// Rate - number of requests per second
+	Rate float64 `json:"rate"`
+	// Burst - max number of requests allowed at once, default is the rate rounded up
+	Burst int `json:"burst"`
+	// MaxConnections - max number of concurrent connections
+	MaxConnections int `json:"maxConnections"`
+}
+
+// Config - admission control config, limits for the specific clients and network services override the default ones:
+//
+//	client:
+//	  rate: 10
+//	  burst: 20
+//	  maxConnections: 100
+//	networkService:
+//	  maxConnections: 1000
+//	clients:
+//	  spiffe://example.org/ns/default/sa/nsc:
+//	    maxConnections: 10
+//	networkServices:
+//	  my-service:
+//	    rate: 100
+type Config struct {
+	// Client - default limits for each client identity
+	Client Limits `json:"client"`
+	// NetworkService - default limits for each network service
+	NetworkService Limits `json:"networkService"`
+	// Clients - limits for the specific client identities
+	Clients map[string]Limits `json:"clients"`
+	// NetworkServices - limits for the specific network services
+	NetworkServices map[string]Limits `json:"networkServices"`
+}
+
+func parseConfig(bytes []byte) (*Config, error) {
+	config := new(Config)
+	if err := yaml.Unmarshal(bytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal admission config")
+	}
+	return config, nil
+}
+
+func (c *Config) clientLimits(client string) Limits {
+	if limits, ok := c.Clients[client]; ok {
+		return limits
+	}
+	return c.Client
+}
+
+func (c *Config) networkServiceLimits(networkService string) Limits {
+	if limits, ok := c.NetworkServices[networkService]; ok {
+		return limits
+	}
+	return c.NetworkService
+}
+
+func (l *Limits) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return math.Max(1, math.Ceil(l.Rate))
+}
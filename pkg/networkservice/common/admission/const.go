@@ -0,0 +1,26 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+const (
+	// configFile - admission control config file name
+	configFile = "admission.yaml"
+	// nsmConfigDir - admission control config file directory name
+	nsmConfigDir = "/var/lib/networkservicemesh/config"
+	// ConfigFilePathDefault - admission control config file absolute path
+	ConfigFilePathDefault = nsmConfigDir + "/" + configFile
+)
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// clientIdentity - returns SPIFFE ID of the peer certificate, or the first path segment name if there is no SPIFFE ID
+func clientIdentity(ctx context.Context, conn *networkservice.Connection) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if cert := peerCertificate(p.AuthInfo); cert != nil {
+			if spiffeID, err := x509svid.IDFromCert(cert); err == nil {
+				return spiffeID.String()
+			}
+		}
+	}
+	if segments := conn.GetPath().GetPathSegments(); len(segments) > 0 {
+		return segments[0].GetName()
+	}
+	return ""
+}
+
+func peerCertificate(authInfo credentials.AuthInfo) *x509.Certificate {
+	var tlsInfo *credentials.TLSInfo
+	switch v := authInfo.(type) {
+	case *credentials.TLSInfo:
+		tlsInfo = v
+	case credentials.TLSInfo:
+		tlsInfo = &v
+	default:
+		return nil
+	}
+	if len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil
+	}
+	return tlsInfo.State.PeerCertificates[0]
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission provides a chain element enforcing request rate limits and max concurrent connections per client
+// identity and per network service
+package admission
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const sweepInterval = time.Minute
+
+type quota struct {
+	bucket      *tokenBucket
+	connections int
+}
+
+type admittedConnection struct {
+	client         string
+	networkService string
+	expireTimer    clock.Timer
+}
+
+type admissionServer struct {
+	ctx        context.Context
+	configPath string
+	once       sync.Once
+
+	config          *Config
+	clients         map[string]*quota
+	networkServices map[string]*quota
+	connections     map[string]*admittedConnection
+	lastSweep       time.Time
+	mu              sync.Mutex
+}
+
+// NewServer - creates a networkservice.NetworkServiceServer chain element enforcing admission limits read from the
+// watched config file: request rate (token bucket) and max concurrent connections per client identity and per network
+// service. Client identity is the peer SPIFFE ID, or the first path segment name if there is no SPIFFE ID. Refresh
+// requests for the already admitted connections are not limited, neither are the admitted requests returned to the same
+// chain by the interposed forwarder. Violations are returned as codes.ResourceExhausted. Admitted connection is released
+// on Close or when the previous path segment expires without being refreshed.
+func NewServer(ctx context.Context, options ...Option) networkservice.NetworkServiceServer {
+	s := &admissionServer{
+		ctx:             ctx,
+		configPath:      ConfigFilePathDefault,
+		config:          new(Config),
+		clients:         make(map[string]*quota),
+		networkServices: make(map[string]*quota),
+		connections:     make(map[string]*admittedConnection),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *admissionServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.once.Do(s.init)
+
+	if s.isReturned(request.GetConnection()) {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	connID := request.GetConnection().GetId()
+	expires := request.GetConnection().GetPrevPathSegment().GetExpires()
+
+	admitted, err := s.admit(ctx, connID, &admittedConnection{
+		client:         clientIdentity(ctx, request.GetConnection()),
+		networkService: request.GetConnection().GetNetworkService(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if admitted {
+			s.release(connID)
+		}
+		return nil, err
+	}
+
+	if expires != nil {
+		s.expire(ctx, connID, expires.AsTime())
+	}
+
+	return conn, nil
+}
+
+func (s *admissionServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	defer s.release(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *admissionServer) init() {
+	updateCh := fs.WatchFile(s.ctx, s.configPath)
+	s.updateConfig(<-updateCh)
+	go func() {
+		for update := range updateCh {
+			s.updateConfig(update)
+		}
+	}()
+}
+
+func (s *admissionServer) updateConfig(bytes []byte) {
+	config, err := parseConfig(bytes)
+	if err != nil {
+		log.FromContext(s.ctx).Errorf("Can not update admission config: %v", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config = config
+	// Buckets are recreated with the new limits on demand
+	for _, q := range s.clients {
+		q.bucket = nil
+	}
+	for _, q := range s.networkServices {
+		q.bucket = nil
+	}
+}
+
+// admit - checks limits and counts the new connection, returns true if the connection has been admitted now and false
+// if it has been already admitted before
+func (s *admissionServer) admit(ctx context.Context, connID string, conn *admittedConnection) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.connections[connID]; ok {
+		return false, nil
+	}
+
+	now := clock.FromContext(ctx).Now()
+	if now.Sub(s.lastSweep) > sweepInterval {
+		sweepQuotas(s.clients, now)
+		sweepQuotas(s.networkServices, now)
+		s.lastSweep = now
+	}
+
+	clientLimits := s.config.clientLimits(conn.client)
+	clientQuota := getQuota(s.clients, conn.client)
+	if clientLimits.MaxConnections > 0 && clientQuota.connections >= clientLimits.MaxConnections {
+		return false, status.Errorf(codes.ResourceExhausted, "client %s has reached max connections limit: %d",
+			conn.client, clientLimits.MaxConnections)
+	}
+
+	nsLimits := s.config.networkServiceLimits(conn.networkService)
+	nsQuota := getQuota(s.networkServices, conn.networkService)
+	if nsLimits.MaxConnections > 0 && nsQuota.connections >= nsLimits.MaxConnections {
+		return false, status.Errorf(codes.ResourceExhausted, "network service %s has reached max connections limit: %d",
+			conn.networkService, nsLimits.MaxConnections)
+	}
+
+	if !clientQuota.allow(&clientLimits, now) {
+		return false, status.Errorf(codes.ResourceExhausted, "client %s has exceeded request rate limit: %v/s",
+			conn.client, clientLimits.Rate)
+	}
+	if !nsQuota.allow(&nsLimits, now) {
+		return false, status.Errorf(codes.ResourceExhausted, "network service %s has exceeded request rate limit: %v/s",
+			conn.networkService, nsLimits.Rate)
+	}
+
+	clientQuota.connections++
+	nsQuota.connections++
+	s.connections[connID] = conn
+
+	return true, nil
+}
+
+// isReturned - returns true if the request has been already admitted by this chain on the same path, it happens when the
+// interposed forwarder returns the request back to the chain with the new path segment
+func (s *admissionServer) isReturned(conn *networkservice.Connection) bool {
+	segments := conn.GetPath().GetPathSegments()
+	index := int(conn.GetPath().GetIndex())
+	if index >= len(segments) {
+		return false
+	}
+	name := segments[index].GetName()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < index; i++ {
+		if segments[i].GetName() != name {
+			continue
+		}
+		if _, ok := s.connections[segments[i].GetId()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expire - (re)arms the timer releasing the admitted connection on expiration time
+func (s *admissionServer) expire(ctx context.Context, connID string, expirationTime time.Time) {
+	clk := clock.FromContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, ok := s.connections[connID]
+	if !ok {
+		return
+	}
+	if conn.expireTimer != nil {
+		conn.expireTimer.Stop()
+	}
+	conn.expireTimer = clk.AfterFunc(clk.Until(expirationTime), func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Connection can be already released and admitted again
+		if s.connections[connID] == conn {
+			s.releaseLocked(connID, conn)
+		}
+	})
+}
+
+func (s *admissionServer) release(connID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, ok := s.connections[connID]
+	if !ok {
+		return
+	}
+	s.releaseLocked(connID, conn)
+}
+
+func (s *admissionServer) releaseLocked(connID string, conn *admittedConnection) {
+	if conn.expireTimer != nil {
+		conn.expireTimer.Stop()
+	}
+	delete(s.connections, connID)
+
+	releaseQuota(s.clients, conn.client)
+	releaseQuota(s.networkServices, conn.networkService)
+}
+
+func getQuota(quotas map[string]*quota, key string) *quota {
+	q, ok := quotas[key]
+	if !ok {
+		q = new(quota)
+		quotas[key] = q
+	}
+	return q
+}
+
+func releaseQuota(quotas map[string]*quota, key string) {
+	if q, ok := quotas[key]; ok {
+		q.connections--
+	}
+}
+
+// sweepQuotas - deletes quotas having no connections and a full bucket, they are indistinguishable from the new ones
+func sweepQuotas(quotas map[string]*quota, now time.Time) {
+	for key, q := range quotas {
+		if q.connections <= 0 && (q.bucket == nil || q.bucket.full(now)) {
+			delete(quotas, key)
+		}
+	}
+}
+
+func (q *quota) allow(limits *Limits, now time.Time) bool {
+	if limits.Rate <= 0 {
+		return true
+	}
+	if q.bucket == nil {
+		q.bucket = newTokenBucket(limits, now)
+	}
+	return q.bucket.allow(now)
+}
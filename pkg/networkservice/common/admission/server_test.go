@@ -0,0 +1,212 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/admission"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func newRequest(connID, client, networkService string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             connID,
+			NetworkService: networkService,
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: client}},
+			},
+		},
+	}
+}
+
+func writeConfig(t *testing.T, configPath, config string) {
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(config), os.ModePerm))
+}
+
+func newServer(ctx context.Context, t *testing.T, config string) (networkservice.NetworkServiceServer, string) {
+	dir, err := ioutil.TempDir("", "admission")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	configPath := filepath.Join(dir, "admission.yaml")
+	writeConfig(t, configPath, config)
+
+	return admission.NewServer(ctx, admission.WithConfigPath(configPath)), configPath
+}
+
+func requireResourceExhausted(t *testing.T, err error) {
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestAdmissionServer_MaxConnections(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, _ := newServer(ctx, t, `
+client:
+  maxConnections: 2
+networkService:
+  maxConnections: 3
+`)
+
+	_, err := server.Request(ctx, newRequest("1", "nsc-1", "ns"))
+	require.NoError(t, err)
+	conn, err := server.Request(ctx, newRequest("2", "nsc-1", "ns"))
+	require.NoError(t, err)
+
+	// Client limit
+	_, err = server.Request(ctx, newRequest("3", "nsc-1", "ns"))
+	requireResourceExhausted(t, err)
+
+	// Refresh is allowed
+	_, err = server.Request(ctx, newRequest("2", "nsc-1", "ns"))
+	require.NoError(t, err)
+
+	// Network service limit
+	_, err = server.Request(ctx, newRequest("3", "nsc-2", "ns"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("4", "nsc-2", "ns"))
+	requireResourceExhausted(t, err)
+	_, err = server.Request(ctx, newRequest("4", "nsc-2", "other-ns"))
+	require.NoError(t, err)
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+
+	_, err = server.Request(ctx, newRequest("5", "nsc-1", "ns"))
+	require.NoError(t, err)
+}
+
+func TestAdmissionServer_RateLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.NewMock()
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server, _ := newServer(ctx, t, `
+client:
+  rate: 1
+  burst: 2
+clients:
+  nsc-2:
+    rate: 10
+`)
+
+	_, err := server.Request(ctx, newRequest("1", "nsc-1", "ns"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("2", "nsc-1", "ns"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("3", "nsc-1", "ns"))
+	requireResourceExhausted(t, err)
+
+	// Other client has its own limits
+	for _, connID := range []string{"4", "5", "6"} {
+		_, err = server.Request(ctx, newRequest(connID, "nsc-2", "ns"))
+		require.NoError(t, err)
+	}
+
+	clockMock.Add(time.Second)
+
+	_, err = server.Request(ctx, newRequest("3", "nsc-1", "ns"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("7", "nsc-1", "ns"))
+	requireResourceExhausted(t, err)
+}
+
+func TestAdmissionServer_Expiration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.NewMock()
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server, _ := newServer(ctx, t, `
+client:
+  maxConnections: 1
+`)
+
+	request := func(connID string) *networkservice.NetworkServiceRequest {
+		request := newRequest(connID, "nsc", "ns")
+		request.GetConnection().GetPath().GetPathSegments()[0].Expires = timestamppb.New(clockMock.Now().Add(time.Minute))
+		request.GetConnection().GetPath().PathSegments = append(request.GetConnection().GetPath().GetPathSegments(),
+			&networkservice.PathSegment{Name: "nsmgr"})
+		request.GetConnection().GetPath().Index = 1
+		return request
+	}
+
+	_, err := server.Request(ctx, request("1"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("2"))
+	requireResourceExhausted(t, err)
+
+	// Refresh extends the expiration time
+	clockMock.Add(time.Minute / 2)
+	_, err = server.Request(ctx, request("1"))
+	require.NoError(t, err)
+
+	clockMock.Add(time.Minute / 2)
+	_, err = server.Request(ctx, request("2"))
+	requireResourceExhausted(t, err)
+
+	// Expired connection releases the slot without Close
+	clockMock.Add(time.Minute)
+	require.Eventually(t, func() bool {
+		_, err = server.Request(ctx, request("2"))
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAdmissionServer_ConfigReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, configPath := newServer(ctx, t, `
+client:
+  maxConnections: 1
+`)
+
+	_, err := server.Request(ctx, newRequest("1", "nsc", "ns"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, newRequest("2", "nsc", "ns"))
+	requireResourceExhausted(t, err)
+
+	writeConfig(t, configPath, `
+client:
+  maxConnections: 2
+`)
+
+	require.Eventually(t, func() bool {
+		_, err = server.Request(ctx, newRequest("2", "nsc", "ns"))
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
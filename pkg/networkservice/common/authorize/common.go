@@ -19,8 +19,6 @@ package authorize
 import (
 	"context"
 
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-
 	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 )
 
@@ -32,7 +30,7 @@ type Policy interface {
 
 type policiesList []Policy
 
-func (l *policiesList) check(ctx context.Context, conn *networkservice.Connection) error {
+func (l *policiesList) check(ctx context.Context, model interface{}) error {
 	if l == nil {
 		return nil
 	}
@@ -40,7 +38,7 @@ func (l *policiesList) check(ctx context.Context, conn *networkservice.Connectio
 		if p == nil {
 			continue
 		}
-		if err := p.Check(ctx, conn.GetPath()); err != nil {
+		if err := p.Check(ctx, model); err != nil {
 			return err
 		}
 	}
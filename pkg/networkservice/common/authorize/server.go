@@ -45,7 +45,7 @@ func NewServer(opts ...Option) networkservice.NetworkServiceServer {
 }
 
 func (a *authorizeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	if err := a.policies.check(ctx, request.GetConnection()); err != nil {
+	if err := a.policies.check(ctx, request); err != nil {
 		return nil, err
 	}
 	return next.Server(ctx).Request(ctx, request)
@@ -24,10 +24,20 @@ import (
 	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
-// isSubset checks if B is a subset of A. TODO: reconsider this as a part of "tools"
+// isSubset checks if B is a subset of A, matchutils.SelectorKey value of B is evaluated as a selector expression
+// against A. TODO: reconsider this as a part of "tools"
 func isSubset(a, b, nsLabels map[string]string) bool {
+	if expr, ok := b[matchutils.SelectorKey]; ok {
+		selector, err := matchutils.ParseSelector(ProcessLabels(expr, nsLabels))
+		if err != nil || !selector.Matches(a) {
+			return false
+		}
+		b = withoutKey(b, matchutils.SelectorKey)
+	}
 	if len(a) < len(b) {
 		return false
 	}
@@ -42,6 +52,16 @@ func isSubset(a, b, nsLabels map[string]string) bool {
 	return true
 }
 
+func withoutKey(m map[string]string, key string) map[string]string {
+	rv := make(map[string]string, len(m))
+	for k, v := range m {
+		if k != key {
+			rv[k] = v
+		}
+	}
+	return rv
+}
+
 func matchEndpoint(nsLabels map[string]string, ns *registry.NetworkService, networkServiceEndpoints ...*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
 	var validNetworkServiceEndpoints []*registry.NetworkServiceEndpoint
 	for _, nse := range networkServiceEndpoints {
@@ -39,6 +39,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	registrynext "github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
 func endpoints() []*registry.NetworkServiceEndpoint {
@@ -218,6 +219,50 @@ func TestMatchNonEmptySourceSelector(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestMatchSelectorExpression(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	nsName := networkServiceName()
+
+	nsServer, nseServer := testServers(t, nsName, endpoints(), &registry.Match{
+		SourceSelector: map[string]string{
+			matchutils.SelectorKey: "app in (firewall, some-middle-app)",
+		},
+		Routes: []*registry.Destination{
+			{
+				DestinationSelector: map[string]string{
+					matchutils.SelectorKey: "app notin (firewall, some-middle-app)",
+				},
+			},
+		},
+	})
+
+	want := labels(nsName, map[string]string{
+		"app": "vpn-gateway",
+	})
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: nsName,
+			Labels: map[string]string{
+				"app": "firewall",
+			},
+		},
+	}
+
+	server := next.NewNetworkServiceServer(
+		discover.NewServer(adapters.NetworkServiceServerToClient(nsServer), adapters.NetworkServiceEndpointServerToClient(nseServer)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			nses := discover.Candidates(ctx).Endpoints
+			require.Len(t, nses, 1)
+			require.Equal(t, want, nses[0].NetworkServiceLabels)
+		}),
+	)
+
+	_, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+}
+
 func TestMatchEmptySourceSelectorGoingFirst(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 
@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heal
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultInitialDelay = 100 * time.Millisecond
+	defaultMultiplier   = 2.
+	defaultMaxDelay     = 5 * time.Second
+	defaultJitter       = 0.2
+)
+
+type healPolicy struct {
+	initialDelay time.Duration
+	multiplier   float64
+	maxDelay     time.Duration
+	jitter       float64
+	maxAttempts  int
+	budget       time.Duration
+}
+
+func defaultHealPolicy() healPolicy {
+	return healPolicy{
+		initialDelay: defaultInitialDelay,
+		multiplier:   defaultMultiplier,
+		maxDelay:     defaultMaxDelay,
+		jitter:       defaultJitter,
+	}
+}
+
+// backoff tracks heal attempts for a single connection
+type backoff struct {
+	policy   *healPolicy
+	clock    clock.Clock
+	start    time.Time
+	delay    time.Duration
+	attempts int
+	done     bool
+}
+
+func (p *healPolicy) newBackoff(ctx context.Context) *backoff {
+	clk := clock.FromContext(ctx)
+	return &backoff{
+		policy: p,
+		clock:  clk,
+		start:  clk.Now(),
+		delay:  p.initialDelay,
+	}
+}
+
+// wait - should be called after each failed heal attempt. Waits for the next attempt and returns true, or returns
+// false if ctx is done or the heal policy is exhausted.
+func (b *backoff) wait(ctx context.Context) bool {
+	b.attempts++
+	if b.policy.maxAttempts > 0 && b.attempts >= b.policy.maxAttempts {
+		b.done = true
+		return false
+	}
+
+	delay := b.jittered()
+	if b.policy.budget > 0 && b.clock.Since(b.start)+delay > b.policy.budget {
+		b.done = true
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-b.clock.After(delay):
+	}
+
+	b.delay = time.Duration(float64(b.delay) * b.policy.multiplier)
+	if b.policy.maxDelay > 0 && b.delay > b.policy.maxDelay {
+		b.delay = b.policy.maxDelay
+	}
+	return true
+}
+
+// exhausted - returns true if there are no more heal attempts allowed by the heal policy
+func (b *backoff) exhausted() bool {
+	return b.done
+}
+
+func (b *backoff) jittered() time.Duration {
+	if b.policy.jitter <= 0 {
+		return b.delay
+	}
+	// #nosec
+	factor := 1 + b.policy.jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(b.delay) * factor)
+}
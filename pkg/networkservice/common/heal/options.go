@@ -0,0 +1,67 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heal
+
+import "time"
+
+// Option is an option for the heal server
+type Option func(f *healServer)
+
+// WithInitialDelay sets delay before the second heal attempt, default is 100ms
+func WithInitialDelay(initialDelay time.Duration) Option {
+	return func(f *healServer) {
+		f.policy.initialDelay = initialDelay
+	}
+}
+
+// WithDelayMultiplier sets multiplier applied to the delay after each failed heal attempt, default is 2
+func WithDelayMultiplier(multiplier float64) Option {
+	return func(f *healServer) {
+		f.policy.multiplier = multiplier
+	}
+}
+
+// WithMaxDelay sets max delay between heal attempts, default is 5s
+func WithMaxDelay(maxDelay time.Duration) Option {
+	return func(f *healServer) {
+		f.policy.maxDelay = maxDelay
+	}
+}
+
+// WithJitter sets jitter as a fraction of the delay: each delay is randomly picked from
+// [delay * (1 - jitter), delay * (1 + jitter)], default is 0.2
+func WithJitter(jitter float64) Option {
+	return func(f *healServer) {
+		f.policy.jitter = jitter
+	}
+}
+
+// WithMaxAttempts sets max number of heal attempts, default is 0 meaning unlimited. When all attempts have failed,
+// connection is closed.
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(f *healServer) {
+		f.policy.maxAttempts = maxAttempts
+	}
+}
+
+// WithBudget sets max time to spend on healing a connection, default is 0 meaning unlimited. When the budget runs
+// out, connection is closed.
+func WithBudget(budget time.Duration) Option {
+	return func(f *healServer) {
+		f.policy.budget = budget
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/addressof"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 )
 
 type ctxWrapper struct {
@@ -60,6 +61,7 @@ type healServer struct {
 	cancelHealMap         map[string]*ctxWrapper
 	cancelHealMapExecutor serialize.Executor
 	conns                 connectionMap
+	policy                healPolicy
 }
 
 // NewServer - creates a new networkservice.NetworkServiceServer chain element that implements the healing algorithm
@@ -75,11 +77,16 @@ type healServer struct {
 //                        If we are part of a larger chain or a server, we should pass the resulting chain into
 //                        this constructor before we actually have a pointer to it.
 //                        If onHeal nil, onHeal will be pointed to the returned networkservice.NetworkServiceClient
-func NewServer(ctx context.Context, onHeal *networkservice.NetworkServiceClient) networkservice.NetworkServiceServer {
+//             - options - heal policy options
+func NewServer(ctx context.Context, onHeal *networkservice.NetworkServiceClient, options ...Option) networkservice.NetworkServiceServer {
 	rv := &healServer{
 		ctx:           ctx,
 		onHeal:        onHeal,
 		cancelHealMap: make(map[string]*ctxWrapper),
+		policy:        defaultHealPolicy(),
+	}
+	for _, opt := range options {
+		opt(rv)
 	}
 
 	if rv.onHeal == nil {
@@ -233,6 +240,9 @@ func (f *healServer) healAsNeeded(baseCtx context.Context, request *networkservi
 func (f *healServer) healContext(baseCtx context.Context) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(f.ctx)
 
+	// Heal runs in the background on the chain context, so keep reporting to the metrics of the original Request
+	ctx = metrics.WithMetrics(ctx, metrics.FromContext(baseCtx))
+
 	if candidates := discover.Candidates(baseCtx); candidates != nil {
 		ctx = discover.WithCandidates(ctx, candidates.Endpoints, candidates.NetworkService)
 	}
@@ -330,7 +340,7 @@ func (f *healServer) processEvent(ctx context.Context, request *networkservice.N
 		fallthrough
 	case networkservice.ConnectionEventType_DELETE:
 		if event.Connections != nil && event.Connections[pathSegment.GetId()] != nil {
-			f.processHeal(ctx, request, opts...)
+			f.processHeal(ctx, request, nil, opts...)
 		}
 	}
 	return nil
@@ -363,16 +373,24 @@ func (f *healServer) restoreConnection(ctx context.Context, request *networkserv
 	requestCtx, requestCancel := context.WithDeadline(ctx, deadline)
 	defer requestCancel()
 
-	for requestCtx.Err() == nil {
-		if _, err = (*f.onHeal).Request(requestCtx, request.Clone(), opts...); err == nil {
+	b := f.policy.newBackoff(ctx)
+	for {
+		_, err = (*f.onHeal).Request(requestCtx, request.Clone(), opts...)
+		metrics.FromContext(ctx).HealAttempt(request.GetConnection().GetNetworkService(), err)
+		if err == nil {
 			return
 		}
+		if !b.wait(requestCtx) {
+			break
+		}
 	}
 
-	f.processHeal(ctx, request.Clone(), opts...)
+	f.processHeal(ctx, request.Clone(), b, opts...)
 }
 
-func (f *healServer) processHeal(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) {
+// processHeal - re-requests the connection with reselect, b is used to continue an already started heal process and
+// can be nil.
+func (f *healServer) processHeal(ctx context.Context, request *networkservice.NetworkServiceRequest, b *backoff, opts ...grpc.CallOption) {
 	logEntry := log.FromContext(ctx).WithField("healServer", "processHeal")
 	conn := request.GetConnection()
 
@@ -388,15 +406,27 @@ func (f *healServer) processHeal(ctx context.Context, request *networkservice.Ne
 		path := reRequest.GetConnection().Path
 		reRequest.GetConnection().Path.PathSegments = path.PathSegments[0 : path.Index+1]
 
-		for ctx.Err() == nil {
+		if b == nil {
+			b = f.policy.newBackoff(ctx)
+		}
+		for !b.exhausted() {
 			_, err := (*f.onHeal).Request(healCtx, reRequest, opts...)
-			if err != nil {
-				logEntry.Errorf("Failed to heal connection %s: %v", conn.GetId(), err)
-			} else {
+			metrics.FromContext(ctx).HealAttempt(conn.GetNetworkService(), err)
+			if err == nil {
 				logEntry.Infof("Finished heal process for %s", conn.GetId())
+				return
+			}
+			logEntry.Errorf("Failed to heal connection %s: %v", conn.GetId(), err)
+			if !b.wait(ctx) {
 				break
 			}
 		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		logEntry.Errorf("Heal policy is exhausted for %s, closing the connection", conn.GetId())
+		f.closeConnection(request.GetConnection().Clone(), opts...)
 	} else {
 		// Huge timeout is not required to close connection on a current path segment
 		closeCtx, closeCancel := context.WithTimeout(ctx, time.Second)
@@ -409,6 +439,17 @@ func (f *healServer) processHeal(ctx context.Context, request *networkservice.Ne
 	}
 }
 
+// closeConnection - closes the connection with onHeal, so the whole chain including monitor gets informed about it.
+// Since Close stops heal for the connection and so cancels the heal context, f.ctx is used for the Close context.
+func (f *healServer) closeConnection(conn *networkservice.Connection, opts ...grpc.CallOption) {
+	closeCtx, closeCancel := context.WithTimeout(f.ctx, time.Second)
+	defer closeCancel()
+
+	if _, err := (*f.onHeal).Close(closeCtx, conn, opts...); err != nil {
+		log.FromContext(f.ctx).WithField("healServer", "closeConnection").Errorf("Failed to close connection %s: %v", conn.GetId(), err)
+	}
+}
+
 func (f *healServer) replaceConnectionPath(conn *networkservice.Connection) {
 	path := conn.GetPath()
 	if path != nil && int(path.Index) < len(path.PathSegments)-1 {
@@ -18,10 +18,12 @@ package heal_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
@@ -162,3 +164,63 @@ func TestHealClient_EmptyInit(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestHealClient_MaxAttempts(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	eventCh := make(chan *networkservice.ConnectionEvent, 1)
+	defer close(eventCh)
+
+	var requests int32
+	onHealCloseCh := make(chan struct{})
+	onHeal := &testOnHeal{
+		RequestFunc: func(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+			atomic.AddInt32(&requests, 1)
+			return nil, errors.New("failed to heal")
+		},
+		CloseFunc: func(ctx context.Context, in *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+			close(onHealCloseCh)
+			return new(empty.Empty), nil
+		},
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	monitorServer := eventchannel.NewMonitorServer(eventCh)
+	server := chain.NewNetworkServiceServer(
+		updatepath.NewServer("testServer"),
+		monitor.NewServer(ctx, &monitorServer),
+		updatetoken.NewServer(sandbox.GenerateTestToken),
+	)
+	healServer := heal.NewServer(ctx, addressof.NetworkServiceClient(onHeal),
+		heal.WithInitialDelay(time.Millisecond),
+		heal.WithMaxAttempts(3))
+	client := chain.NewNetworkServiceClient(
+		updatepath.NewClient("testClient"),
+		adapters.NewServerToClient(healServer),
+		heal.NewClient(ctx, adapters.NewMonitorServerToClient(monitorServer)),
+		adapters.NewServerToClient(updatetoken.NewServer(sandbox.GenerateTestToken)),
+		adapters.NewServerToClient(server),
+	)
+
+	requestCtx, reqCancelFunc := context.WithTimeout(ctx, waitForTimeout)
+	defer reqCancelFunc()
+	conn, err := client.Request(requestCtx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: "ns-1",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = server.Close(requestCtx, conn.Clone())
+	require.NoError(t, err)
+
+	select {
+	case <-time.After(waitHealTimeout):
+		require.FailNow(t, "timeout waiting for Close")
+	case <-onHealCloseCh:
+	}
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMaxFileSize    = 10 * 1024 * 1024
+	defaultMaxFileBackups = 3
+)
+
+// FileSink - Sink appending entries as JSON lines to the local file. When the file exceeds max size, it is rotated:
+// renamed to <path>.1, previous <path>.1 is renamed to <path>.2 and so on up to max backups.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+	mu   sync.Mutex
+}
+
+// FileSinkOption is an option for the FileSink
+type FileSinkOption func(s *FileSink)
+
+// WithMaxFileSize sets max size of the journal file in bytes, default is 10MB
+func WithMaxFileSize(maxSize int64) FileSinkOption {
+	if maxSize < 1 {
+		panic("max file size should be positive")
+	}
+	return func(s *FileSink) {
+		s.maxSize = maxSize
+	}
+}
+
+// WithMaxFileBackups sets max number of rotated journal files to keep, default is 3
+func WithMaxFileBackups(maxBackups int) FileSinkOption {
+	if maxBackups < 0 {
+		panic("max file backups should be non-negative")
+	}
+	return func(s *FileSink) {
+		s.maxBackups = maxBackups
+	}
+}
+
+// NewFileSink creates a new FileSink appending entries to the file at the path
+func NewFileSink(path string, options ...FileSinkOption) (*FileSink, error) {
+	s := &FileSink{
+		path:       path,
+		maxSize:    defaultMaxFileSize,
+		maxBackups: defaultMaxFileBackups,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Publish - appends the entry to the file, rotating the file if needed
+func (s *FileSink) Publish(entry *Entry) error {
+	js, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal entry")
+	}
+	js = append(js, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.Errorf("journal file %s is closed", s.path)
+	}
+
+	if s.size > 0 && s.size+int64(len(js)) > s.maxSize {
+		if err = s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(js)
+	s.size += int64(n)
+	return errors.Wrapf(err, "failed to write journal entry to %s", s.path)
+}
+
+// Close - closes the file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open journal file %s", s.path)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "failed to stat journal file %s", s.path)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close journal file %s", s.path)
+	}
+	s.file = nil
+
+	if s.maxBackups == 0 {
+		if err := os.Remove(s.path); err != nil {
+			return errors.Wrapf(err, "failed to remove journal file %s", s.path)
+		}
+		return s.open()
+	}
+
+	for i := s.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to rotate journal file %s", s.backupPath(i))
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return errors.Wrapf(err, "failed to rotate journal file %s", s.path)
+	}
+
+	return s.open()
+}
+
+func (s *FileSink) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
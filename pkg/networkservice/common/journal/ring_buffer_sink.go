@@ -0,0 +1,73 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// RingBufferSink - Sink keeping last entries in memory, can be used in tests or served over HTTP as a debug endpoint
+type RingBufferSink struct {
+	entries []*Entry
+	next    int
+	full    bool
+	mu      sync.RWMutex
+}
+
+// NewRingBufferSink creates a new RingBufferSink keeping last capacity entries
+func NewRingBufferSink(capacity int) *RingBufferSink {
+	if capacity < 1 {
+		panic("capacity should be positive")
+	}
+	return &RingBufferSink{
+		entries: make([]*Entry, capacity),
+	}
+}
+
+// Publish - stores the entry, overwriting the oldest one if the buffer is full
+func (s *RingBufferSink) Publish(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[s.next] = entry
+	s.next = (s.next + 1) % len(s.entries)
+	if s.next == 0 {
+		s.full = true
+	}
+	return nil
+}
+
+// Entries - returns stored entries from the oldest to the newest
+func (s *RingBufferSink) Entries() []*Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.full {
+		return append([]*Entry(nil), s.entries[:s.next]...)
+	}
+	return append(append([]*Entry(nil), s.entries[s.next:]...), s.entries[:s.next]...)
+}
+
+// ServeHTTP - responds with stored entries as a JSON array
+func (s *RingBufferSink) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Entries()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -14,23 +14,24 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package journal emits IP and PATH related event messages to NATS.
+// Package journal emits IP and PATH related event messages to the journal Sink: NATS Streaming, local file, in-memory
+// ring buffer or HTTP webhook.
 // The journal may be used for healing IPAM and/or auditing
 // connection activity.
 package journal
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/edwarnicke/serialize"
 	"github.com/golang/protobuf/ptypes/empty"
 	stan "github.com/nats-io/stan.go"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 // ActionRequest indicates that the event seen is a connection request.
@@ -40,78 +41,110 @@ const ActionRequest = "request"
 // ActionClose indicates that the event captured is a connection close.
 const ActionClose = "close"
 
-// Entry is populated and serialized to NATS.
+// Entry is populated and published to the Sink.
 type Entry struct {
 	Time        time.Time
 	Source      string
 	Destination string
 	Action      string
 	Path        *networkservice.Path
+	Labels      map[string]string
 }
 
+const defaultBufferSize = 1024
+
 type journalServer struct {
-	journalID string
-	nats      stan.Conn
+	// pending and dropped are accessed atomically, so they are first to be 64-bit aligned
+	pending    int64
+	dropped    uint64
+	bufferSize int64
+	sink       Sink
+	executor   serialize.Executor
 }
 
-func (srv *journalServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	conn, err := next.Server(ctx).Request(ctx, request)
+// SinkServerOption is an option for the journaling server
+type SinkServerOption func(srv *journalServer)
+
+// WithBufferSize sets max number of the entries waiting to be published to the sink, new entries are dropped while
+// the buffer is full. Default is 1024.
+func WithBufferSize(size int) SinkServerOption {
+	if size <= 0 {
+		panic("size should be positive")
+	}
+	return func(srv *journalServer) {
+		srv.bufferSize = int64(size)
+	}
+}
+
+// NewServer creates a new journaling server with the name journalID using provided streaming NATS connection
+func NewServer(journalID string, stanConn stan.Conn) (networkservice.NetworkServiceServer, error) {
+	sink, err := NewSTANSink(journalID, stanConn)
 	if err != nil {
-		return conn, err
+		return nil, err
 	}
+	return NewSinkServer(sink), nil
+}
 
-	src := conn.GetContext().GetIpContext().GetSrcIpAddr()
-	dst := conn.GetContext().GetIpContext().GetDstIpAddr()
-	path := conn.GetPath()
+// NewSinkServer creates a new journaling server publishing entries to the sink. Entries are published
+// asynchronously in the order of the Request and Close calls, publish errors are logged and don't fail the calls.
+// Entries are buffered while the sink is busy, if the buffer is full they are dropped and logged.
+func NewSinkServer(sink Sink, options ...SinkServerOption) networkservice.NetworkServiceServer {
+	srv := &journalServer{
+		sink:       sink,
+		bufferSize: defaultBufferSize,
+	}
+	for _, opt := range options {
+		opt(srv)
+	}
+	return srv
+}
 
-	entry := Entry{
-		Time:        time.Now().UTC(),
-		Source:      src,
-		Destination: dst,
-		Action:      ActionRequest,
-		Path:        path,
+func (srv *journalServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return conn, err
 	}
 
-	err = srv.publish(&entry)
+	srv.publish(ctx, conn, ActionRequest)
 
-	return conn, err
+	return conn, nil
 }
-func (srv *journalServer) Close(ctx context.Context, connection *networkservice.Connection) (*empty.Empty, error) {
-	src := connection.GetContext().GetIpContext().GetSrcIpAddr()
-	dst := connection.GetContext().GetIpContext().GetDstIpAddr()
-	entry := Entry{
-		Time:        time.Now().UTC(),
-		Source:      src,
-		Destination: dst,
-		Action:      ActionClose,
-	}
 
-	// squash error if present
-	_ = srv.publish(&entry)
+func (srv *journalServer) Close(ctx context.Context, connection *networkservice.Connection) (*empty.Empty, error) {
+	srv.publish(ctx, connection, ActionClose)
 
 	return next.Server(ctx).Close(ctx, connection)
 }
 
-func (srv *journalServer) publish(entry *Entry) error {
-	js, err := json.Marshal(entry)
-	if err != nil {
-		return err
-	}
+func (srv *journalServer) publish(ctx context.Context, conn *networkservice.Connection, action string) {
+	logger := log.FromContext(ctx).WithField("journalServer", "publish")
 
-	err = srv.nats.Publish(srv.journalID, js)
-	if err != nil {
-		return err
+	if atomic.AddInt64(&srv.pending, 1) > srv.bufferSize {
+		atomic.AddInt64(&srv.pending, -1)
+		dropped := atomic.AddUint64(&srv.dropped, 1)
+		logger.Warnf("journal buffer is full, %s entry for the connection %s is dropped, dropped entries: %d",
+			action, conn.GetId(), dropped)
+		return
 	}
-	return nil
+
+	entry := newEntry(conn, action)
+	srv.executor.AsyncExec(func() {
+		defer atomic.AddInt64(&srv.pending, -1)
+		if err := srv.sink.Publish(entry); err != nil {
+			logger.Warnf("failed to publish journal entry: %s", err.Error())
+		}
+	})
 }
 
-// NewServer creates a new journaling server with the name journalID using provided streaming NATS connection
-func NewServer(journalID string, stanConn stan.Conn) (networkservice.NetworkServiceServer, error) {
-	if strings.TrimSpace(journalID) == "" {
-		return nil, errors.New("journal id is nil")
+func newEntry(conn *networkservice.Connection, action string) *Entry {
+	// Entry can outlive the connection in the Sink, so it shouldn't share Path and Labels with the connection
+	conn = conn.Clone()
+	return &Entry{
+		Time:        time.Now().UTC(),
+		Source:      conn.GetContext().GetIpContext().GetSrcIpAddr(),
+		Destination: conn.GetContext().GetIpContext().GetDstIpAddr(),
+		Action:      action,
+		Path:        conn.GetPath(),
+		Labels:      conn.GetLabels(),
 	}
-	return &journalServer{
-		journalID: journalID,
-		nats:      stanConn,
-	}, nil
 }
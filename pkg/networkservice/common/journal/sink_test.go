@@ -0,0 +1,229 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/journal"
+)
+
+func testRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Context: &networkservice.ConnectionContext{
+				IpContext: &networkservice.IPContext{
+					SrcIpAddr: "10.0.0.1/32",
+					DstIpAddr: "10.0.0.2/32",
+				},
+			},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc"}},
+			},
+			Labels: map[string]string{"app": "nsc"},
+		},
+	}
+}
+
+func TestRingBufferSink_Server(t *testing.T) {
+	sink := journal.NewRingBufferSink(10)
+	srv := journal.NewSinkServer(sink)
+
+	conn, err := srv.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+
+	_, err = srv.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(sink.Entries()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	entries := sink.Entries()
+
+	require.Equal(t, journal.ActionRequest, entries[0].Action)
+	require.Equal(t, journal.ActionClose, entries[1].Action)
+	for _, entry := range entries {
+		require.Equal(t, "10.0.0.1/32", entry.Source)
+		require.Equal(t, "10.0.0.2/32", entry.Destination)
+		require.Len(t, entry.Path.GetPathSegments(), 1)
+		require.Equal(t, map[string]string{"app": "nsc"}, entry.Labels)
+	}
+}
+
+func TestRingBufferSink_Overflow(t *testing.T) {
+	sink := journal.NewRingBufferSink(3)
+
+	for _, action := range []string{"1", "2", "3", "4", "5"} {
+		require.NoError(t, sink.Publish(&journal.Entry{Action: action}))
+	}
+
+	var actions []string
+	for _, entry := range sink.Entries() {
+		actions = append(actions, entry.Action)
+	}
+	require.Equal(t, []string{"3", "4", "5"}, actions)
+
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/journal", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var entries []*journal.Entry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	require.Len(t, entries, 3)
+}
+
+func TestFileSink_Rotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "journal.log")
+
+	entry := &journal.Entry{Action: journal.ActionRequest}
+	js, err := json.Marshal(entry)
+	require.NoError(t, err)
+	lineSize := int64(len(js) + 1)
+
+	sink, err := journal.NewFileSink(path,
+		journal.WithMaxFileSize(2*lineSize),
+		journal.WithMaxFileBackups(2))
+	require.NoError(t, err)
+	defer func() { _ = sink.Close() }()
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sink.Publish(entry))
+	}
+
+	require.Equal(t, 1, countLines(t, path))
+	require.Equal(t, 2, countLines(t, path+".1"))
+	require.Equal(t, 2, countLines(t, path+".2"))
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestWebhookSink(t *testing.T) {
+	entries := make(chan *journal.Entry, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entry := new(journal.Entry)
+		if err := json.NewDecoder(r.Body).Decode(entry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		entries <- entry
+	}))
+	defer ts.Close()
+
+	srv := journal.NewSinkServer(journal.NewWebhookSink(ts.URL))
+
+	_, err := srv.Close(context.Background(), testRequest().GetConnection())
+	require.NoError(t, err)
+
+	entry := <-entries
+	require.Equal(t, journal.ActionClose, entry.Action)
+	require.Equal(t, map[string]string{"app": "nsc"}, entry.Labels)
+}
+
+func TestWebhookSink_Error(t *testing.T) {
+	called := make(chan struct{}, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called <- struct{}{}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	srv := journal.NewSinkServer(journal.NewWebhookSink(ts.URL))
+
+	conn, err := srv.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+
+	<-called
+}
+
+type blockingSink struct {
+	entries chan *journal.Entry
+}
+
+func (s *blockingSink) Publish(entry *journal.Entry) error {
+	s.entries <- entry
+	return nil
+}
+
+func TestSinkServer_BufferOverflow(t *testing.T) {
+	sink := &blockingSink{entries: make(chan *journal.Entry)}
+	srv := journal.NewSinkServer(sink, journal.WithBufferSize(2))
+
+	// The first entry is blocked in the sink, the second one is buffered, the rest are dropped
+	for i := 0; i < 4; i++ {
+		_, err := srv.Request(context.Background(), testRequest())
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case entry := <-sink.entries:
+			require.Equal(t, journal.ActionRequest, entry.Action)
+		case <-time.After(time.Second):
+			require.FailNow(t, "entry is not published")
+		}
+	}
+
+	select {
+	case <-sink.entries:
+		require.FailNow(t, "dropped entry is published")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Buffer is free again
+	_, err := srv.Close(context.Background(), testRequest().GetConnection())
+	require.NoError(t, err)
+
+	select {
+	case entry := <-sink.entries:
+		require.Equal(t, journal.ActionClose, entry.Action)
+	case <-time.After(time.Second):
+		require.FailNow(t, "entry is not published")
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	file, err := os.Open(filepath.Clean(path))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var count int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		count++
+	}
+	require.NoError(t, scanner.Err())
+	return count
+}
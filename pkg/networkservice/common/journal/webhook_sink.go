@@ -0,0 +1,80 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultWebhookTimeout = 5 * time.Second
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// WebhookSinkOption is an option for the webhook Sink
+type WebhookSinkOption func(s *webhookSink)
+
+// WithHTTPClient sets HTTP client to send entries with, default is a client with 5s timeout
+func WithHTTPClient(client *http.Client) WebhookSinkOption {
+	return func(s *webhookSink) {
+		s.client = client
+	}
+}
+
+// NewWebhookSink creates a new Sink sending each entry as a JSON body of the HTTP POST request to the url
+func NewWebhookSink(url string, options ...WebhookSinkOption) Sink {
+	s := &webhookSink{
+		url: url,
+		client: &http.Client{
+			Timeout: defaultWebhookTimeout,
+		},
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *webhookSink) Publish(entry *Entry) error {
+	js, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal entry")
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(js))
+	if err != nil {
+		return errors.Wrapf(err, "failed to send journal entry to %s", s.url)
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("failed to send journal entry to %s: %s", s.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type memifMechanismClient struct {
+	role           string
+	socketFilename string
+}
+
+// NewClient - returns client that sets memif preferred mechanism
+func NewClient(options ...Option) networkservice.NetworkServiceClient {
+	m := &memifMechanismClient{
+		role: RoleSlave,
+	}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+func (m *memifMechanismClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if !m.updateMechanismPreferences(request) {
+		mechanism := &networkservice.Mechanism{
+			Cls:        cls.LOCAL,
+			Type:       memif.MECHANISM,
+			Parameters: make(map[string]string),
+		}
+		m.setParameters(mechanism.Parameters)
+		request.MechanismPreferences = append(request.GetMechanismPreferences(), mechanism)
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (m *memifMechanismClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// updateMechanismPreferences returns true if MechanismPreferences has updated
+func (m *memifMechanismClient) updateMechanismPreferences(request *networkservice.NetworkServiceRequest) bool {
+	var updated = false
+
+	for _, mechanism := range request.GetRequestMechanismPreferences() {
+		if mechanism.Type == memif.MECHANISM {
+			if mechanism.Parameters == nil {
+				mechanism.Parameters = make(map[string]string)
+			}
+			m.setParameters(mechanism.Parameters)
+			updated = true
+		}
+	}
+
+	return updated
+}
+
+func (m *memifMechanismClient) setParameters(parameters map[string]string) {
+	if parameters[RoleKey] == "" {
+		parameters[RoleKey] = m.role
+	}
+	if parameters[SocketFilenameKey] == "" && m.socketFilename != "" {
+		parameters[SocketFilenameKey] = m.socketFilename
+	}
+	// Memif master creates the socket file in its netns
+	if parameters[RoleKey] == RoleMaster && parameters[common.InodeURL] == "" {
+		parameters[common.InodeURL] = (&url.URL{Scheme: "file", Path: netNSFilename}).String()
+	}
+}
+
+// Option for memif mechanism client
+type Option func(m *memifMechanismClient)
+
+// WithRole sets memif role of the client: RoleMaster or RoleSlave, default is RoleSlave
+func WithRole(role string) Option {
+	if role != RoleMaster && role != RoleSlave {
+		panic("memif role should be either master or slave: " + role)
+	}
+	return func(m *memifMechanismClient) {
+		m.role = role
+	}
+}
+
+// WithSocketFilename sets memif socket filename, by default it is generated by the server
+func WithSocketFilename(socketFilename string) Option {
+	return func(m *memifMechanismClient) {
+		m.socketFilename = socketFilename
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/memif"
+)
+
+func Test_MemifClient_ShouldSetSlaveByDefault(t *testing.T) {
+	c := memif.NewClient()
+
+	req := &networkservice.NetworkServiceRequest{}
+	_, err := c.Request(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, req.MechanismPreferences, 1)
+	require.Equal(t, memifmech.MECHANISM, req.MechanismPreferences[0].Type)
+	require.Equal(t, memif.RoleSlave, req.MechanismPreferences[0].Parameters[memif.RoleKey])
+	require.Empty(t, req.MechanismPreferences[0].Parameters[common.InodeURL])
+	require.Empty(t, req.MechanismPreferences[0].Parameters[memif.SocketFilenameKey])
+}
+
+func Test_MemifClient_Master(t *testing.T) {
+	c := memif.NewClient(memif.WithRole(memif.RoleMaster), memif.WithSocketFilename("/memif.sock"))
+
+	req := &networkservice.NetworkServiceRequest{}
+	_, err := c.Request(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, req.MechanismPreferences, 1)
+	require.Equal(t, memif.RoleMaster, req.MechanismPreferences[0].Parameters[memif.RoleKey])
+	require.Equal(t, "file:///proc/thread-self/ns/net", req.MechanismPreferences[0].Parameters[common.InodeURL])
+	require.Equal(t, "/memif.sock", req.MechanismPreferences[0].Parameters[memif.SocketFilenameKey])
+}
+
+func Test_MemifClient_ShouldNotDoublingMechanisms(t *testing.T) {
+	c := memif.NewClient()
+
+	req := &networkservice.NetworkServiceRequest{}
+
+	for i := 0; i < 10; i++ {
+		_, err := c.Request(context.Background(), req)
+		require.NoError(t, err)
+		require.Len(t, req.MechanismPreferences, 1)
+	}
+}
+
+func Test_MemifClient_InvalidRole(t *testing.T) {
+	require.Panics(t, func() {
+		memif.WithRole("invalid")
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif
+
+const (
+	// SocketFilenameKey - memif socket filename parameter key. Socket file is located in the netns referred by the
+	// common.InodeURL parameter, so it is passed across the connection with sendfd/recvfd.
+	SocketFilenameKey = "socketfile"
+	// RoleKey - memif role of the client side parameter key
+	RoleKey = "role"
+
+	// RoleMaster - client side is memif master: it creates the socket file in its netns
+	RoleMaster = "master"
+	// RoleSlave - client side is memif slave: server side creates the socket file in its netns
+	RoleSlave = "slave"
+
+	defaultSocketDir = "/var/lib/networkservicemesh/memif"
+	socketFilename   = "memif.socket"
+	netNSFilename    = "/proc/thread-self/ns/net"
+)
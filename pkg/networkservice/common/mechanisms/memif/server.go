@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memif provides the necessary mechanisms to request and create a memif interface.
+package memif
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type memifMechanismServer struct {
+	socketDir string
+}
+
+// NewServer - creates a NetworkServiceServer that generates memif socket filename and populates the netns inode of the
+//             memif master
+func NewServer(options ...ServerOption) networkservice.NetworkServiceServer {
+	m := &memifMechanismServer{
+		socketDir: defaultSocketDir,
+	}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+func (m *memifMechanismServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := request.GetConnection().GetMechanism()
+	if mechanism.GetType() != memif.MECHANISM {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	parameters := mechanism.GetParameters()
+
+	switch parameters[RoleKey] {
+	case RoleMaster:
+		if parameters[common.InodeURL] == "" {
+			return nil, errors.New("memif master netns URL is not set")
+		}
+	case RoleSlave, "":
+		// Server side is memif master, so the socket file is created in its netns
+		parameters[RoleKey] = RoleSlave
+		parameters[common.InodeURL] = (&url.URL{Scheme: "file", Path: netNSFilename}).String()
+	default:
+		return nil, errors.Errorf("unknown memif role: %s", parameters[RoleKey])
+	}
+
+	if parameters[SocketFilenameKey] == "" {
+		parameters[SocketFilenameKey] = filepath.Join(m.socketDir, request.GetConnection().GetId(), socketFilename)
+	}
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (m *memifMechanismServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// ServerOption for memif mechanism server
+type ServerOption func(m *memifMechanismServer)
+
+// WithSocketDir sets directory for the generated memif socket files, default is /var/lib/networkservicemesh/memif
+func WithSocketDir(socketDir string) ServerOption {
+	return func(m *memifMechanismServer) {
+		m.socketDir = socketDir
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memif_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	memifmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/memif"
+)
+
+func newRequest(connID string, parameters map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Mechanism: &networkservice.Mechanism{
+				Cls:        cls.LOCAL,
+				Type:       memifmech.MECHANISM,
+				Parameters: parameters,
+			},
+		},
+	}
+}
+
+func Test_MemifServer_Slave(t *testing.T) {
+	s := memif.NewServer(memif.WithSocketDir("/memif"))
+
+	conn, err := s.Request(context.Background(), newRequest("conn-1", map[string]string{
+		memif.RoleKey: memif.RoleSlave,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "/memif/conn-1/memif.socket", conn.GetMechanism().GetParameters()[memif.SocketFilenameKey])
+	require.Equal(t, "file:///proc/thread-self/ns/net", conn.GetMechanism().GetParameters()[common.InodeURL])
+
+	conn, err = s.Request(context.Background(), newRequest("conn-2", nil))
+	require.NoError(t, err)
+	require.Equal(t, "/memif/conn-2/memif.socket", conn.GetMechanism().GetParameters()[memif.SocketFilenameKey])
+	require.Equal(t, memif.RoleSlave, conn.GetMechanism().GetParameters()[memif.RoleKey])
+}
+
+func Test_MemifServer_Master(t *testing.T) {
+	s := memif.NewServer(memif.WithSocketDir("/memif"))
+
+	conn, err := s.Request(context.Background(), newRequest("conn-1", map[string]string{
+		memif.RoleKey:           memif.RoleMaster,
+		memif.SocketFilenameKey: "/client/memif.sock",
+		common.InodeURL:         "file:///proc/1/fd/10",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "/client/memif.sock", conn.GetMechanism().GetParameters()[memif.SocketFilenameKey])
+	require.Equal(t, "file:///proc/1/fd/10", conn.GetMechanism().GetParameters()[common.InodeURL])
+
+	_, err = s.Request(context.Background(), newRequest("conn-2", map[string]string{
+		memif.RoleKey: memif.RoleMaster,
+	}))
+	require.Error(t, err)
+
+	_, err = s.Request(context.Background(), newRequest("conn-3", map[string]string{
+		memif.RoleKey: "invalid",
+	}))
+	require.Error(t, err)
+}
+
+func Test_MemifServer_Refresh(t *testing.T) {
+	s := memif.NewServer()
+
+	conn, err := s.Request(context.Background(), newRequest("conn-1", nil))
+	require.NoError(t, err)
+	socketFilename := conn.GetMechanism().GetParameters()[memif.SocketFilenameKey]
+	require.NotEmpty(t, socketFilename)
+
+	conn, err = s.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, socketFilename, conn.GetMechanism().GetParameters()[memif.SocketFilenameKey])
+}
+
+func Test_MemifServer_OtherMechanism(t *testing.T) {
+	s := memif.NewServer()
+
+	request := newRequest("conn-1", nil)
+	request.Connection.Mechanism.Type = kernel.MECHANISM
+
+	conn, err := s.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Empty(t, conn.GetMechanism().GetParameters())
+}
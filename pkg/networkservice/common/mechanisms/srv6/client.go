@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type srv6MechanismClient struct {
+	pool *sidPool
+}
+
+// NewClient - returns client that sets srv6 preferred mechanism with the source SIDs allocated from the locator prefix.
+//             SIDs are kept on refresh while srv6 is the selected mechanism and released on Close.
+func NewClient(locator *net.IPNet) networkservice.NetworkServiceClient {
+	return &srv6MechanismClient{
+		pool: newSIDPool(locator),
+	}
+}
+
+func (s *srv6MechanismClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	srcSIDs, loaded := loadSIDs(ctx, true)
+	if !loaded {
+		var err error
+		if srcSIDs, err = s.pool.allocate(); err != nil {
+			return nil, err
+		}
+		storeSIDs(ctx, true, srcSIDs)
+	}
+
+	if !updateMechanismPreferences(request, srcSIDs) {
+		request.MechanismPreferences = append(request.GetMechanismPreferences(), &networkservice.Mechanism{
+			Cls:  cls.REMOTE,
+			Type: srv6.MECHANISM,
+			Parameters: map[string]string{
+				SrcBSIDKey:     srcSIDs.bsid.String(),
+				SrcLocalSIDKey: srcSIDs.localSID.String(),
+			},
+		})
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		if !loaded {
+			s.pool.release(srcSIDs)
+			deleteSIDs(ctx, true)
+		}
+		return nil, err
+	}
+
+	if conn.GetMechanism().GetType() != srv6.MECHANISM {
+		// SIDs are offered with the preferred mechanism, but are kept only if srv6 has been selected
+		s.pool.release(srcSIDs)
+		deleteSIDs(ctx, true)
+	}
+
+	return conn, nil
+}
+
+func (s *srv6MechanismClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if srcSIDs, ok := loadSIDs(ctx, true); ok {
+		s.pool.release(srcSIDs)
+		deleteSIDs(ctx, true)
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// updateMechanismPreferences returns true if MechanismPreferences has updated
+func updateMechanismPreferences(request *networkservice.NetworkServiceRequest, srcSIDs *sids) bool {
+	var updated = false
+
+	for _, m := range request.GetRequestMechanismPreferences() {
+		if m.Type == srv6.MECHANISM {
+			if m.Parameters == nil {
+				m.Parameters = make(map[string]string)
+			}
+			m.Parameters[SrcBSIDKey] = srcSIDs.bsid.String()
+			m.Parameters[SrcLocalSIDKey] = srcSIDs.localSID.String()
+			updated = true
+		}
+	}
+
+	return updated
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	srv6mech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/srv6"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+// selectMechanism - selects the preferred mechanism of the mechanismType as the server does
+func selectMechanism(request *networkservice.NetworkServiceRequest, mechanismType string) {
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: mechanismType,
+	}
+	for _, m := range request.GetMechanismPreferences() {
+		if m.GetType() == mechanismType {
+			request.GetConnection().Mechanism = m.Clone()
+		}
+	}
+}
+
+func TestSRv6Client_AllocateSIDs(t *testing.T) {
+	client := next.NewNetworkServiceClient(
+		metadata.NewClient(),
+		srv6.NewClient(newLocator(t, "fc00::/126")),
+		checkrequest.NewClient(t, func(_ *testing.T, request *networkservice.NetworkServiceRequest) {
+			selectMechanism(request, srv6mech.MECHANISM)
+		}),
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "1"},
+	}
+	conn1, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Len(t, request.MechanismPreferences, 1)
+	require.Equal(t, cls.REMOTE, request.MechanismPreferences[0].Cls)
+	require.Equal(t, srv6mech.MECHANISM, request.MechanismPreferences[0].Type)
+	require.Equal(t, "fc00::", request.MechanismPreferences[0].Parameters[srv6.SrcBSIDKey])
+	require.Equal(t, "fc00::1", request.MechanismPreferences[0].Parameters[srv6.SrcLocalSIDKey])
+
+	// Refresh keeps SIDs and doesn't double the mechanism
+	_, err = client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, request.MechanismPreferences, 1)
+	require.Equal(t, "fc00::", request.MechanismPreferences[0].Parameters[srv6.SrcBSIDKey])
+
+	request2 := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "2"},
+	}
+	_, err = client.Request(context.Background(), request2)
+	require.NoError(t, err)
+	require.Equal(t, "fc00::2", request2.MechanismPreferences[0].Parameters[srv6.SrcBSIDKey])
+	require.Equal(t, "fc00::3", request2.MechanismPreferences[0].Parameters[srv6.SrcLocalSIDKey])
+
+	_, err = client.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	request3 := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "3"},
+	}
+	_, err = client.Request(context.Background(), request3)
+	require.NoError(t, err)
+	require.Equal(t, "fc00::", request3.MechanismPreferences[0].Parameters[srv6.SrcBSIDKey])
+}
+
+func TestSRv6Client_NotSelected(t *testing.T) {
+	selected := kernel.MECHANISM
+	client := next.NewNetworkServiceClient(
+		metadata.NewClient(),
+		srv6.NewClient(newLocator(t, "fc00::/126")),
+		checkrequest.NewClient(t, func(_ *testing.T, request *networkservice.NetworkServiceRequest) {
+			selectMechanism(request, selected)
+		}),
+	)
+
+	request1 := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "1"},
+	}
+	conn1, err := client.Request(context.Background(), request1)
+	require.NoError(t, err)
+	require.Equal(t, kernel.MECHANISM, conn1.GetMechanism().GetType())
+
+	// SIDs offered to the not selected mechanism are released
+	selected = srv6mech.MECHANISM
+
+	request2 := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "2"},
+	}
+	conn2, err := client.Request(context.Background(), request2)
+	require.NoError(t, err)
+	require.Equal(t, srv6mech.MECHANISM, conn2.GetMechanism().GetType())
+	require.Equal(t, "fc00::", conn2.GetMechanism().GetParameters()[srv6.SrcBSIDKey])
+
+	request1.Connection = conn1
+	conn1, err = client.Request(context.Background(), request1)
+	require.NoError(t, err)
+	require.Equal(t, srv6mech.MECHANISM, conn1.GetMechanism().GetType())
+	require.Equal(t, "fc00::2", conn1.GetMechanism().GetParameters()[srv6.SrcBSIDKey])
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package srv6 provides the necessary mechanisms to request and allocate SIDs for a SRv6 connection.
+package srv6
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type srv6MechanismServer struct {
+	pool *sidPool
+}
+
+// NewServer - creates a NetworkServiceServer that sets the destination SIDs allocated from the locator prefix.
+//             SIDs are kept on refresh and released on Close.
+func NewServer(locator *net.IPNet) networkservice.NetworkServiceServer {
+	return &srv6MechanismServer{
+		pool: newSIDPool(locator),
+	}
+}
+
+func (s *srv6MechanismServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := request.GetConnection().GetMechanism()
+	if mechanism.GetType() != srv6.MECHANISM {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	dstSIDs, loaded := loadSIDs(ctx, false)
+	if !loaded {
+		var err error
+		if dstSIDs, err = s.pool.allocate(); err != nil {
+			return nil, err
+		}
+		storeSIDs(ctx, false, dstSIDs)
+	}
+
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.Parameters[DstBSIDKey] = dstSIDs.bsid.String()
+	mechanism.Parameters[DstLocalSIDKey] = dstSIDs.localSID.String()
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !loaded {
+			s.pool.release(dstSIDs)
+			deleteSIDs(ctx, false)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *srv6MechanismServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if dstSIDs, ok := loadSIDs(ctx, false); ok {
+		s.pool.release(dstSIDs)
+		deleteSIDs(ctx, false)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
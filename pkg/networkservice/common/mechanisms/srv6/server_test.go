@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	srv6mech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/srv6"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/srv6"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newLocator(t *testing.T, prefix string) *net.IPNet {
+	_, locator, err := net.ParseCIDR(prefix)
+	require.NoError(t, err)
+	return locator
+}
+
+func newRequest(connID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.REMOTE,
+				Type: srv6mech.MECHANISM,
+			},
+		},
+	}
+}
+
+func TestSRv6Server_AllocateSIDs(t *testing.T) {
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		srv6.NewServer(newLocator(t, "fc00::/127")),
+	)
+
+	conn1, err := server.Request(context.Background(), newRequest("1"))
+	require.NoError(t, err)
+	require.Equal(t, "fc00::", conn1.GetMechanism().GetParameters()[srv6.DstBSIDKey])
+	require.Equal(t, "fc00::1", conn1.GetMechanism().GetParameters()[srv6.DstLocalSIDKey])
+
+	// Refresh keeps SIDs
+	conn1, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn1})
+	require.NoError(t, err)
+	require.Equal(t, "fc00::", conn1.GetMechanism().GetParameters()[srv6.DstBSIDKey])
+	require.Equal(t, "fc00::1", conn1.GetMechanism().GetParameters()[srv6.DstLocalSIDKey])
+
+	// Locator is exhausted
+	_, err = server.Request(context.Background(), newRequest("2"))
+	require.Error(t, err)
+
+	_, err = server.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn2, err := server.Request(context.Background(), newRequest("2"))
+	require.NoError(t, err)
+	require.Equal(t, "fc00::", conn2.GetMechanism().GetParameters()[srv6.DstBSIDKey])
+	require.Equal(t, "fc00::1", conn2.GetMechanism().GetParameters()[srv6.DstLocalSIDKey])
+}
+
+func TestSRv6Server_InvalidLocator(t *testing.T) {
+	require.Panics(t, func() {
+		srv6.NewServer(newLocator(t, "10.0.0.0/24"))
+	})
+}
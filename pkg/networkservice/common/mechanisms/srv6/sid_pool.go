@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv6
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+)
+
+// sids - SIDs allocated for one side of the connection
+type sids struct {
+	bsid     net.IP
+	localSID net.IP
+}
+
+type sidPool struct {
+	ipPool *ippool.IPPool
+}
+
+func newSIDPool(locator *net.IPNet) *sidPool {
+	if locator == nil || len(locator.IP) != net.IPv6len {
+		panic("SRv6 locator should be IPv6 prefix")
+	}
+	return &sidPool{
+		ipPool: ippool.NewWithNet(locator),
+	}
+}
+
+func (p *sidPool) allocate() (*sids, error) {
+	bsid, err := p.ipPool.Pull()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to allocate binding SID")
+	}
+	localSID, err := p.ipPool.Pull()
+	if err != nil {
+		p.ipPool.Add(bsid)
+		return nil, errors.Wrap(err, "failed to allocate local SID")
+	}
+	return &sids{
+		bsid:     bsid,
+		localSID: localSID,
+	}, nil
+}
+
+func (p *sidPool) release(s *sids) {
+	p.ipPool.Add(s.bsid)
+	p.ipPool.Add(s.localSID)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type vfioMechanismClient struct{}
+
+// NewClient - returns client that sets vfio preferred mechanism
+func NewClient() networkservice.NetworkServiceClient {
+	return &vfioMechanismClient{}
+}
+
+func (v *vfioMechanismClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	for _, m := range request.GetRequestMechanismPreferences() {
+		if m.Type == vfio.MECHANISM {
+			return next.Client(ctx).Request(ctx, request, opts...)
+		}
+	}
+	request.MechanismPreferences = append(request.GetMechanismPreferences(), &networkservice.Mechanism{
+		Cls:        cls.LOCAL,
+		Type:       vfio.MECHANISM,
+		Parameters: make(map[string]string),
+	})
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (v *vfioMechanismClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vfio"
+)
+
+func TestVFIOClient_AddsMechanism(t *testing.T) {
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "1"},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Cls:  cls.LOCAL,
+				Type: kernel.MECHANISM,
+			},
+		},
+	}
+
+	client := vfio.NewClient()
+
+	_, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, request.GetMechanismPreferences(), 2)
+	require.Equal(t, cls.LOCAL, request.GetMechanismPreferences()[1].GetCls())
+	require.Equal(t, vfiomech.MECHANISM, request.GetMechanismPreferences()[1].GetType())
+
+	// Refresh doesn't double the mechanism
+	_, err = client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, request.GetMechanismPreferences(), 2)
+
+	_, err = client.Close(context.Background(), request.GetConnection())
+	require.NoError(t, err)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Device - VFIO device available for the connections
+type Device struct {
+	// PCIAddress - PCI address of the device, e.g. 0000:01:00.0
+	PCIAddress string
+	// IommuGroup - IOMMU group of the device
+	IommuGroup uint32
+}
+
+// devicePool - VFIO devices owned by the connections. VFIO isolation unit is an IOMMU group, so the whole group is
+// owned by the connection the device is selected for.
+type devicePool struct {
+	devices []*Device
+	owners  map[uint32]string
+	mu      sync.Mutex
+}
+
+func newDevicePool(devices []*Device) *devicePool {
+	pciAddresses := make(map[string]struct{})
+	for _, device := range devices {
+		if device == nil || device.PCIAddress == "" {
+			panic("VFIO device should have PCI address")
+		}
+		if _, ok := pciAddresses[device.PCIAddress]; ok {
+			panic("VFIO device is duplicated: " + device.PCIAddress)
+		}
+		pciAddresses[device.PCIAddress] = struct{}{}
+	}
+	return &devicePool{
+		devices: devices,
+		owners:  make(map[uint32]string),
+	}
+}
+
+// selectDevice - selects the device from the free IOMMU group for the connection ID and makes the connection the owner
+// of the group. If iommuGroup is not nil, only devices from this IOMMU group are selected.
+func (p *devicePool) selectDevice(connID string, iommuGroup *uint32) (*Device, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, device := range p.devices {
+		if iommuGroup != nil && device.IommuGroup != *iommuGroup {
+			continue
+		}
+		if _, ok := p.owners[device.IommuGroup]; !ok {
+			p.owners[device.IommuGroup] = connID
+			return device, nil
+		}
+	}
+	return nil, errors.Errorf("no free VFIO device for the connection: %s", connID)
+}
+
+// release - releases the device IOMMU group if it is owned by the connection ID
+func (p *devicePool) release(connID string, device *Device) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if owner, ok := p.owners[device.IommuGroup]; ok && owner == connID {
+		delete(p.owners, device.IommuGroup)
+	}
+}
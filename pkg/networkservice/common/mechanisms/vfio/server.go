@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vfio provides the necessary mechanisms to request and select a VFIO device.
+package vfio
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type vfioMechanismServer struct {
+	pool *devicePool
+}
+
+// NewServer - creates a NetworkServiceServer that selects a free device from the devices and passes its PCI address
+//             and IOMMU group in the mechanism parameters. Device IOMMU group is exclusively owned by the connection
+//             until Close, so devices sharing the group are not selected for the other connections.
+//             If the mechanism has IommuGroupKey parameter set, only devices from this IOMMU group are selected.
+func NewServer(devices []*Device) networkservice.NetworkServiceServer {
+	return &vfioMechanismServer{
+		pool: newDevicePool(devices),
+	}
+}
+
+func (v *vfioMechanismServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := request.GetConnection().GetMechanism()
+	if mechanism.GetType() != vfio.MECHANISM {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	parameters := mechanism.GetParameters()
+
+	device, loaded := loadDevice(ctx)
+	if !loaded {
+		var iommuGroup *uint32
+		if iommuGroupStr := parameters[IommuGroupKey]; iommuGroupStr != "" {
+			value, err := strconv.ParseUint(iommuGroupStr, 10, 32)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid IOMMU group: %s", iommuGroupStr)
+			}
+			group := uint32(value)
+			iommuGroup = &group
+		}
+
+		var err error
+		if device, err = v.pool.selectDevice(request.GetConnection().GetId(), iommuGroup); err != nil {
+			return nil, err
+		}
+		storeDevice(ctx, device)
+	}
+
+	parameters[PCIAddressKey] = device.PCIAddress
+	parameters[IommuGroupKey] = strconv.FormatUint(uint64(device.IommuGroup), 10)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !loaded {
+			v.pool.release(request.GetConnection().GetId(), device)
+			deleteDevice(ctx)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (v *vfioMechanismServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if device, ok := loadDevice(ctx); ok {
+		v.pool.release(conn.GetId(), device)
+		deleteDevice(ctx)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
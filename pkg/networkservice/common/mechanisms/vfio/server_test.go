@@ -0,0 +1,144 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vfio"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newServer() networkservice.NetworkServiceServer {
+	return next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vfio.NewServer([]*vfio.Device{
+			{PCIAddress: "0000:01:00.0", IommuGroup: 1},
+			{PCIAddress: "0000:01:00.1", IommuGroup: 1},
+			{PCIAddress: "0000:02:00.0", IommuGroup: 2},
+		}),
+	)
+}
+
+func newRequest(connID string, parameters map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Mechanism: &networkservice.Mechanism{
+				Cls:        cls.LOCAL,
+				Type:       vfiomech.MECHANISM,
+				Parameters: parameters,
+			},
+		},
+	}
+}
+
+func pciAddress(conn *networkservice.Connection) string {
+	return conn.GetMechanism().GetParameters()[vfio.PCIAddressKey]
+}
+
+func TestVFIOServer_ExclusiveOwnership(t *testing.T) {
+	server := newServer()
+
+	conn1, err := server.Request(context.Background(), newRequest("1", nil))
+	require.NoError(t, err)
+	require.Equal(t, "0000:01:00.0", pciAddress(conn1))
+	require.Equal(t, "1", conn1.GetMechanism().GetParameters()[vfio.IommuGroupKey])
+
+	conn1, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn1})
+	require.NoError(t, err)
+	require.Equal(t, "0000:01:00.0", pciAddress(conn1))
+
+	conn2, err := server.Request(context.Background(), newRequest("2", nil))
+	require.NoError(t, err)
+	require.Equal(t, "0000:02:00.0", pciAddress(conn2))
+
+	_, err = server.Request(context.Background(), newRequest("3", nil))
+	require.Error(t, err)
+
+	_, err = server.Close(context.Background(), conn2)
+	require.NoError(t, err)
+
+	conn3, err := server.Request(context.Background(), newRequest("3", nil))
+	require.NoError(t, err)
+	require.Equal(t, "0000:02:00.0", pciAddress(conn3))
+}
+
+func TestVFIOServer_SharedIommuGroup(t *testing.T) {
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vfio.NewServer([]*vfio.Device{
+			{PCIAddress: "0000:01:00.0", IommuGroup: 1},
+			{PCIAddress: "0000:01:00.1", IommuGroup: 1},
+		}),
+	)
+
+	conn1, err := server.Request(context.Background(), newRequest("1", nil))
+	require.NoError(t, err)
+	require.Equal(t, "0000:01:00.0", pciAddress(conn1))
+
+	// The other device shares the IOMMU group with the owned one, so it cannot be given to the other connection
+	_, err = server.Request(context.Background(), newRequest("2", nil))
+	require.Error(t, err)
+
+	_, err = server.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn2, err := server.Request(context.Background(), newRequest("2", nil))
+	require.NoError(t, err)
+	require.Equal(t, "1", conn2.GetMechanism().GetParameters()[vfio.IommuGroupKey])
+}
+
+func TestVFIOServer_IommuGroup(t *testing.T) {
+	server := newServer()
+
+	conn, err := server.Request(context.Background(), newRequest("1", map[string]string{
+		vfio.IommuGroupKey: "2",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "0000:02:00.0", pciAddress(conn))
+
+	_, err = server.Request(context.Background(), newRequest("2", map[string]string{
+		vfio.IommuGroupKey: "2",
+	}))
+	require.Error(t, err)
+
+	_, err = server.Request(context.Background(), newRequest("3", map[string]string{
+		vfio.IommuGroupKey: "invalid",
+	}))
+	require.Error(t, err)
+}
+
+func TestVFIOServer_InvalidDevices(t *testing.T) {
+	require.Panics(t, func() {
+		vfio.NewServer([]*vfio.Device{{IommuGroup: 1}})
+	})
+	require.Panics(t, func() {
+		vfio.NewServer([]*vfio.Device{
+			{PCIAddress: "0000:01:00.0", IommuGroup: 1},
+			{PCIAddress: "0000:01:00.0", IommuGroup: 1},
+		})
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type vxlanMechanismClient struct {
+	srcIP net.IP
+}
+
+// NewClient - returns client that sets vxlan preferred mechanism with srcIP as a source IP of the tunnel
+func NewClient(srcIP net.IP) networkservice.NetworkServiceClient {
+	return &vxlanMechanismClient{
+		srcIP: srcIP,
+	}
+}
+
+func (v *vxlanMechanismClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if !v.updateMechanismPreferences(request) {
+		request.MechanismPreferences = append(request.GetMechanismPreferences(), &networkservice.Mechanism{
+			Cls:  cls.REMOTE,
+			Type: vxlan.MECHANISM,
+			Parameters: map[string]string{
+				common.SrcIP: v.srcIP.String(),
+			},
+		})
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (v *vxlanMechanismClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// updateMechanismPreferences returns true if MechanismPreferences has updated
+func (v *vxlanMechanismClient) updateMechanismPreferences(request *networkservice.NetworkServiceRequest) bool {
+	var updated = false
+
+	for _, m := range request.GetRequestMechanismPreferences() {
+		if m.Type == vxlan.MECHANISM {
+			if m.Parameters == nil {
+				m.Parameters = make(map[string]string)
+			}
+			if m.Parameters[common.SrcIP] == "" {
+				m.Parameters[common.SrcIP] = v.srcIP.String()
+			}
+			updated = true
+		}
+	}
+
+	return updated
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	vxlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vxlan"
+)
+
+func TestVXLANClient_ShouldSetSrcIP(t *testing.T) {
+	c := vxlan.NewClient(net.ParseIP("10.0.0.1"))
+
+	req := &networkservice.NetworkServiceRequest{}
+	for i := 0; i < 10; i++ {
+		_, err := c.Request(context.Background(), req)
+		require.NoError(t, err)
+
+		require.Len(t, req.MechanismPreferences, 1)
+		require.Equal(t, cls.REMOTE, req.MechanismPreferences[0].Cls)
+		require.Equal(t, vxlanmech.MECHANISM, req.MechanismPreferences[0].Type)
+		require.Equal(t, "10.0.0.1", req.MechanismPreferences[0].Parameters[common.SrcIP])
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type keyType struct{}
+
+type vniInfo struct {
+	key tunnelKey
+	vni uint32
+}
+
+func storeVNIInfo(ctx context.Context, info *vniInfo) {
+	metadata.Map(ctx, false).Store(keyType{}, info)
+}
+
+func loadVNIInfo(ctx context.Context) (*vniInfo, bool) {
+	if raw, ok := metadata.Map(ctx, false).Load(keyType{}); ok {
+		return raw.(*vniInfo), true
+	}
+	return nil, false
+}
+
+func deleteVNIInfo(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(keyType{})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vxlan provides the necessary mechanisms to request and allocate parameters of a vxlan tunnel.
+package vxlan
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type vxlanMechanismServer struct {
+	dstIP  net.IP
+	minVNI uint32
+	maxVNI uint32
+	pool   *vniPool
+}
+
+// NewServer - creates a NetworkServiceServer that sets dstIP as a destination IP of the vxlan tunnel and allocates VNI
+//             unique for the tunnel endpoints pair.
+//             VNI is kept on refresh and released on Close.
+func NewServer(dstIP net.IP, options ...Option) networkservice.NetworkServiceServer {
+	v := &vxlanMechanismServer{
+		dstIP:  dstIP,
+		minVNI: MinVNI,
+		maxVNI: MaxVNI,
+	}
+	for _, opt := range options {
+		opt(v)
+	}
+	v.pool = newVNIPool(v.minVNI, v.maxVNI)
+	return v
+}
+
+func (v *vxlanMechanismServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := request.GetConnection().GetMechanism()
+	if mechanism.GetType() != vxlan.MECHANISM {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	parameters := mechanism.GetParameters()
+
+	if parameters[common.SrcIP] == "" {
+		return nil, errors.New("vxlan source IP is not set")
+	}
+	if parameters[common.DstIP] == "" {
+		parameters[common.DstIP] = v.dstIP.String()
+	}
+	key := newTunnelKey(parameters[common.SrcIP], parameters[common.DstIP])
+
+	info, loaded := loadVNIInfo(ctx)
+	if loaded && info.key != key {
+		// tunnel endpoints have changed
+		v.pool.release(info.key, info.vni)
+		deleteVNIInfo(ctx)
+		loaded = false
+	}
+	if !loaded {
+		info = &vniInfo{key: key}
+
+		// VNI can be set by the previous instance of the server, so try to keep it
+		if vni, err := strconv.ParseUint(parameters[VNIKey], 10, 32); err == nil && v.pool.claim(key, uint32(vni)) {
+			info.vni = uint32(vni)
+		} else if info.vni, err = v.pool.allocate(key); err != nil {
+			return nil, err
+		}
+		storeVNIInfo(ctx, info)
+	}
+	parameters[VNIKey] = strconv.FormatUint(uint64(info.vni), 10)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !loaded {
+			v.pool.release(info.key, info.vni)
+			deleteVNIInfo(ctx)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (v *vxlanMechanismServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if info, ok := loadVNIInfo(ctx); ok {
+		v.pool.release(info.key, info.vni)
+		deleteVNIInfo(ctx)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// Option for vxlan mechanism server
+type Option func(v *vxlanMechanismServer)
+
+// WithVNIRange sets range of VNIs to allocate from, default is [MinVNI, MaxVNI]
+func WithVNIRange(minVNI, maxVNI uint32) Option {
+	if minVNI < MinVNI || maxVNI > MaxVNI || minVNI > maxVNI {
+		panic("VNI range should be in [1, 2^24 - 1]")
+	}
+	return func(v *vxlanMechanismServer) {
+		v.minVNI = minVNI
+		v.maxVNI = maxVNI
+	}
+}
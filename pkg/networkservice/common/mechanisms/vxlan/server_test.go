@@ -0,0 +1,175 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	vxlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newServer(options ...vxlan.Option) networkservice.NetworkServiceServer {
+	return next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vxlan.NewServer(net.ParseIP("10.0.0.2"), options...),
+	)
+}
+
+func newRequest(connID, srcIP string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.REMOTE,
+				Type: vxlanmech.MECHANISM,
+				Parameters: map[string]string{
+					common.SrcIP: srcIP,
+				},
+			},
+		},
+	}
+}
+
+func vni(conn *networkservice.Connection) string {
+	return conn.GetMechanism().GetParameters()[vxlan.VNIKey]
+}
+
+func TestVXLANServer_AllocateVNI(t *testing.T) {
+	server := newServer(vxlan.WithVNIRange(10, 11))
+
+	conn1, err := server.Request(context.Background(), newRequest("1", "10.0.0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.2", conn1.GetMechanism().GetParameters()[common.DstIP])
+	require.Equal(t, "10", vni(conn1))
+
+	conn2, err := server.Request(context.Background(), newRequest("2", "10.0.0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "11", vni(conn2))
+
+	_, err = server.Request(context.Background(), newRequest("3", "10.0.0.1"))
+	require.Error(t, err)
+
+	// Other tunnel endpoints pair has its own VNIs
+	conn4, err := server.Request(context.Background(), newRequest("4", "10.0.0.3"))
+	require.NoError(t, err)
+	require.Equal(t, "10", vni(conn4))
+
+	_, err = server.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn5, err := server.Request(context.Background(), newRequest("5", "10.0.0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "10", vni(conn5))
+}
+
+func TestVXLANServer_Refresh(t *testing.T) {
+	server := newServer()
+
+	conn, err := server.Request(context.Background(), newRequest("1", "10.0.0.1"))
+	require.NoError(t, err)
+	expected := vni(conn)
+
+	for i := 0; i < 10; i++ {
+		conn, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+		require.NoError(t, err)
+		require.Equal(t, expected, vni(conn))
+	}
+}
+
+func TestVXLANServer_ReverseDirection(t *testing.T) {
+	server := newServer()
+
+	conn1, err := server.Request(context.Background(), newRequest("1", "10.0.0.1"))
+	require.NoError(t, err)
+
+	request := newRequest("2", "10.0.0.2")
+	request.Connection.Mechanism.Parameters[common.DstIP] = "10.0.0.1"
+
+	conn2, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.NotEqual(t, vni(conn1), vni(conn2))
+}
+
+func TestVXLANServer_KeepRequestedVNI(t *testing.T) {
+	server := newServer()
+
+	request := newRequest("1", "10.0.0.1")
+	request.Connection.Mechanism.Parameters[vxlan.VNIKey] = "100"
+
+	conn1, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "100", vni(conn1))
+
+	request = newRequest("2", "10.0.0.1")
+	request.Connection.Mechanism.Parameters[vxlan.VNIKey] = "100"
+
+	conn2, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.NotEqual(t, "100", vni(conn2))
+}
+
+type failingServer struct {
+	fail bool
+}
+
+func (s *failingServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.fail {
+		return nil, errors.New("failure")
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *failingServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestVXLANServer_NextError(t *testing.T) {
+	failing := &failingServer{fail: true}
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vxlan.NewServer(net.ParseIP("10.0.0.2"), vxlan.WithVNIRange(10, 10)),
+		failing,
+	)
+
+	_, err := server.Request(context.Background(), newRequest("1", "10.0.0.1"))
+	require.Error(t, err)
+
+	failing.fail = false
+
+	conn, err := server.Request(context.Background(), newRequest("2", "10.0.0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "10", vni(conn))
+}
+
+func TestVXLANServer_InvalidRange(t *testing.T) {
+	require.Panics(t, func() { vxlan.WithVNIRange(0, 10) })
+	require.Panics(t, func() { vxlan.WithVNIRange(10, 1<<24) })
+	require.Panics(t, func() { vxlan.WithVNIRange(10, 9) })
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// tunnelKey - VNI is unique only in the scope of the tunnel endpoints pair. Both directions share the same VNIs, because
+// each side receives packets from the other one on the same (remote IP, VNI).
+type tunnelKey struct {
+	srcIP string
+	dstIP string
+}
+
+func newTunnelKey(srcIP, dstIP string) tunnelKey {
+	if dstIP < srcIP {
+		srcIP, dstIP = dstIP, srcIP
+	}
+	return tunnelKey{
+		srcIP: srcIP,
+		dstIP: dstIP,
+	}
+}
+
+type vniPool struct {
+	minVNI  uint32
+	maxVNI  uint32
+	used    map[tunnelKey]map[uint32]struct{}
+	cursors map[tunnelKey]uint32
+	mu      sync.Mutex
+}
+
+func newVNIPool(minVNI, maxVNI uint32) *vniPool {
+	return &vniPool{
+		minVNI:  minVNI,
+		maxVNI:  maxVNI,
+		used:    make(map[tunnelKey]map[uint32]struct{}),
+		cursors: make(map[tunnelKey]uint32),
+	}
+}
+
+// claim - marks VNI used for the tunnel, returns false if it is already used or out of range
+func (p *vniPool) claim(key tunnelKey, vni uint32) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if vni < p.minVNI || vni > p.maxVNI {
+		return false
+	}
+	if _, ok := p.used[key][vni]; ok {
+		return false
+	}
+	p.use(key, vni)
+	return true
+}
+
+// allocate - returns the next free VNI for the tunnel
+func (p *vniPool) allocate(key tunnelKey) (uint32, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	used := p.used[key]
+	if uint64(len(used)) > uint64(p.maxVNI-p.minVNI) {
+		return 0, errors.Errorf("no free VNI for the tunnel %s -> %s", key.srcIP, key.dstIP)
+	}
+
+	vni := p.cursors[key]
+	for {
+		if vni < p.minVNI || vni >= p.maxVNI {
+			vni = p.minVNI
+		} else {
+			vni++
+		}
+		if _, ok := used[vni]; !ok {
+			break
+		}
+	}
+	p.use(key, vni)
+	p.cursors[key] = vni
+	return vni, nil
+}
+
+func (p *vniPool) use(key tunnelKey, vni uint32) {
+	used, ok := p.used[key]
+	if !ok {
+		used = make(map[uint32]struct{})
+		p.used[key] = used
+	}
+	used[vni] = struct{}{}
+}
+
+// release - marks VNI free for the tunnel
+func (p *vniPool) release(key tunnelKey, vni uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	used := p.used[key]
+	delete(used, vni)
+	if len(used) == 0 {
+		delete(p.used, key)
+		delete(p.cursors, key)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+type elementServer struct {
+	element string
+	wrapped networkservice.NetworkServiceServer
+}
+
+// Wrap - returns next.ServerWrapper collecting metrics of the Request and Close calls of every chain element. Each
+//        element is labeled with its type name and is additionally wrapped with the wrapper, e.g.
+//        trace.NewNetworkServiceServer. Metrics are collected to the metrics.FromContext(ctx).
+func Wrap(wrapper next.ServerWrapper) next.ServerWrapper {
+	return func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+		return &elementServer{
+			element: typeutils.GetTypeName(server),
+			wrapped: wrapper(server),
+		}
+	}
+}
+
+func (s *elementServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	start := time.Now()
+
+	conn, err := s.wrapped.Request(ctx, request)
+	metrics.FromContext(ctx).ObserveElement(s.element, requestMethod, start, err)
+
+	return conn, err
+}
+
+func (s *elementServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	start := time.Now()
+
+	rv, err := s.wrapped.Close(ctx, conn)
+	metrics.FromContext(ctx).ObserveElement(s.element, closeMethod, start, err)
+
+	return rv, err
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides chain elements collecting Prometheus metrics of the NetworkService chains
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+const (
+	requestMethod = "Request"
+	closeMethod   = "Close"
+)
+
+type connectionLabels struct {
+	networkService string
+	endpoint       string
+}
+
+type metricsServer struct {
+	name    string
+	metrics *metrics.Metrics
+	conns   connectionMap
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer chain element collecting metrics of the Request and
+//             Close calls of the chain and active connections per network service and endpoint
+//             - name - name of the chain to label the metrics with
+//             - options - metrics server options
+func NewServer(name string, options ...Option) networkservice.NetworkServiceServer {
+	s := &metricsServer{
+		name:    name,
+		metrics: metrics.Default(),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *metricsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	start := time.Now()
+
+	conn, err := next.Server(ctx).Request(metrics.WithMetrics(ctx, s.metrics), request)
+	s.metrics.ObserveRequest(s.name, request.GetConnection().GetNetworkService(), requestMethod, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := connectionLabels{
+		networkService: conn.GetNetworkService(),
+		endpoint:       conn.GetNetworkServiceEndpointName(),
+	}
+	if oldLabels, loaded := s.conns.Load(conn.GetId()); loaded {
+		if oldLabels == labels {
+			return conn, nil
+		}
+		s.metrics.ConnectionClosed(s.name, oldLabels.networkService, oldLabels.endpoint)
+	}
+	s.conns.Store(conn.GetId(), labels)
+	s.metrics.ConnectionOpened(s.name, labels.networkService, labels.endpoint)
+
+	return conn, nil
+}
+
+func (s *metricsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	start := time.Now()
+
+	if labels, loaded := s.conns.LoadAndDelete(conn.GetId()); loaded {
+		s.metrics.ConnectionClosed(s.name, labels.networkService, labels.endpoint)
+	}
+
+	rv, err := next.Server(ctx).Close(metrics.WithMetrics(ctx, s.metrics), conn)
+	s.metrics.ObserveRequest(s.name, conn.GetNetworkService(), closeMethod, start, err)
+
+	return rv, err
+}
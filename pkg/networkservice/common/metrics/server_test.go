@@ -0,0 +1,192 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/metrics"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	metricstools "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+type endpointServer struct {
+	name string
+	err  error
+}
+
+func (s *endpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	request.GetConnection().NetworkServiceEndpointName = s.name
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *endpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+		},
+	}
+}
+
+func gather(t *testing.T, registry *prometheus.Registry, name string) []*dto.Metric {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()
+		}
+	}
+	return nil
+}
+
+func labels(metric *dto.Metric) map[string]string {
+	rv := make(map[string]string)
+	for _, label := range metric.GetLabel() {
+		rv[label.GetName()] = label.GetValue()
+	}
+	return rv
+}
+
+func activeConnections(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	rv := make(map[string]float64)
+	for _, metric := range gather(t, registry, "nsm_networkservice_active_connections") {
+		rv[labels(metric)["network_service_endpoint"]] = metric.GetGauge().GetValue()
+	}
+	return rv
+}
+
+func TestServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	registry := prometheus.NewRegistry()
+	endpoint := &endpointServer{name: "nse-1"}
+	server := next.NewNetworkServiceServer(
+		metrics.NewServer("nsmgr", metrics.WithMetrics(metricstools.New(registry))),
+		endpoint,
+	)
+
+	// 1. Request
+	conn, err := server.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"nse-1": 1}, activeConnections(t, registry))
+
+	// 2. Refresh
+	request := newRequest()
+	request.Connection = conn.Clone()
+	conn, err = server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"nse-1": 1}, activeConnections(t, registry))
+
+	// 3. Heal to another endpoint
+	endpoint.name = "nse-2"
+	request.Connection = conn.Clone()
+	conn, err = server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"nse-1": 0, "nse-2": 1}, activeConnections(t, registry))
+
+	// 4. Close
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"nse-1": 0, "nse-2": 0}, activeConnections(t, registry))
+
+	requests := make(map[string]float64)
+	for _, metric := range gather(t, registry, "nsm_networkservice_requests_total") {
+		l := labels(metric)
+		require.Equal(t, "nsmgr", l["name"])
+		require.Equal(t, "ns", l["network_service"])
+		requests[l["method"]] = metric.GetCounter().GetValue()
+	}
+	require.Equal(t, map[string]float64{"Request": 3, "Close": 1}, requests)
+
+	durations := gather(t, registry, "nsm_networkservice_request_duration_seconds")
+	require.Len(t, durations, 2)
+	for _, metric := range durations {
+		require.NotZero(t, metric.GetHistogram().GetSampleCount())
+	}
+}
+
+func TestServer_Error(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	registry := prometheus.NewRegistry()
+	server := next.NewNetworkServiceServer(
+		metrics.NewServer("nsmgr", metrics.WithMetrics(metricstools.New(registry))),
+		&endpointServer{err: status.Error(codes.PermissionDenied, "permission denied")},
+	)
+
+	_, err := server.Request(context.Background(), newRequest())
+	require.Error(t, err)
+	require.Empty(t, activeConnections(t, registry))
+
+	errs := gather(t, registry, "nsm_networkservice_errors_total")
+	require.Len(t, errs, 1)
+	require.Equal(t, map[string]string{
+		"name":   "nsmgr",
+		"method": "Request",
+		"code":   codes.PermissionDenied.String(),
+	}, labels(errs[0]))
+	require.Equal(t, 1., errs[0].GetCounter().GetValue())
+}
+
+func TestWrap(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	registry := prometheus.NewRegistry()
+	server := next.NewWrappedNetworkServiceServer(
+		metrics.Wrap(func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+			return server
+		}),
+		&endpointServer{name: "nse"},
+		&endpointServer{err: status.Error(codes.Unavailable, "unavailable")},
+	)
+
+	ctx := metricstools.WithMetrics(context.Background(), metricstools.New(registry))
+	_, err := server.Request(ctx, newRequest())
+	require.Error(t, err)
+
+	durations := gather(t, registry, "nsm_networkservice_element_duration_seconds")
+	require.Len(t, durations, 1)
+	require.Equal(t, map[string]string{
+		"element": "sdk/pkg/networkservice/common/metrics_test/endpointServer",
+		"method":  "Request",
+	}, labels(durations[0]))
+	require.Equal(t, uint64(2), durations[0].GetHistogram().GetSampleCount())
+
+	errs := gather(t, registry, "nsm_networkservice_element_errors_total")
+	require.Len(t, errs, 1)
+	require.Equal(t, 2., errs[0].GetCounter().GetValue())
+	require.Equal(t, codes.Unavailable.String(), labels(errs[0])["code"])
+}
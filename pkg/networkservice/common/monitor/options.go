@@ -0,0 +1,49 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+// OverflowPolicy - defines what to do with the subscriber which queue is full
+type OverflowPolicy int
+
+const (
+	// DropAndResync - drops all queued events and sends a fresh INITIAL_STATE_TRANSFER instead of them
+	DropAndResync OverflowPolicy = iota
+	// Disconnect - closes the subscriber MonitorConnections stream with codes.ResourceExhausted
+	Disconnect
+)
+
+const defaultQueueSize = 32
+
+// Option is an option for the monitor server
+type Option func(m *monitorServer)
+
+// WithQueueSize sets max number of events queued for a single subscriber, default is 32
+func WithQueueSize(queueSize int) Option {
+	if queueSize < 1 {
+		panic("queue size should be positive")
+	}
+	return func(m *monitorServer) {
+		m.queueSize = queueSize
+	}
+}
+
+// WithOverflowPolicy sets policy to apply to the subscriber which queue is full, default is DropAndResync
+func WithOverflowPolicy(overflowPolicy OverflowPolicy) Option {
+	return func(m *monitorServer) {
+		m.overflowPolicy = overflowPolicy
+	}
+}
@@ -22,8 +22,11 @@ package monitor
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/edwarnicke/serialize"
 
@@ -34,10 +37,12 @@ import (
 )
 
 type monitorServer struct {
-	connections map[string]*networkservice.Connection
-	monitors    []networkservice.MonitorConnection_MonitorConnectionsServer
-	executor    serialize.Executor
-	ctx         context.Context
+	connections    map[string]*networkservice.Connection
+	subscribers    []*subscriber
+	executor       serialize.Executor
+	ctx            context.Context
+	queueSize      int
+	overflowPolicy OverflowPolicy
 }
 
 // NewServer - creates a NetworkServiceServer chain element that will properly update a MonitorConnectionServer
@@ -49,37 +54,63 @@ type monitorServer struct {
 //                        networkservice.MonitorConnectionServer that can be used either standalone or in a
 //                        networkservice.MonitorConnectionServer chain
 //             ctx - context for lifecycle management
-func NewServer(ctx context.Context, monitorServerPtr *networkservice.MonitorConnectionServer) networkservice.NetworkServiceServer {
+//             options - subscribers queue options
+// Each subscriber has its own bounded events queue, so a slow subscriber doesn't block the others. The
+// networkservice.MonitorConnectionServer also implements LagReporter.
+func NewServer(ctx context.Context, monitorServerPtr *networkservice.MonitorConnectionServer, options ...Option) networkservice.NetworkServiceServer {
 	rv := &monitorServer{
-		ctx:         ctx,
-		connections: make(map[string]*networkservice.Connection),
-		monitors:    nil, // Intentionally nil
+		ctx:            ctx,
+		connections:    make(map[string]*networkservice.Connection),
+		subscribers:    nil, // Intentionally nil
+		queueSize:      defaultQueueSize,
+		overflowPolicy: DropAndResync,
+	}
+	for _, opt := range options {
+		opt(rv)
 	}
 	*monitorServerPtr = rv
 	return rv
 }
 
 func (m *monitorServer) MonitorConnections(selector *networkservice.MonitorScopeSelector, srv networkservice.MonitorConnection_MonitorConnectionsServer) error {
+	sub := newSubscriber(srv.Context(), m.queueSize, newMonitorFilter(selector, srv))
+	defer sub.cancel()
+
 	m.executor.AsyncExec(func() {
-		monitor := newMonitorFilter(selector, srv)
-		m.monitors = append(m.monitors, monitor)
-		connections := make(map[string]*networkservice.Connection)
-		for _, ps := range selector.PathSegments {
-			if conn, ok := m.connections[ps.GetId()]; ok {
-				connections[ps.GetId()] = conn
-			}
-		}
+		m.subscribers = append(m.subscribers, sub)
 		// Send initial transfer of all data available
-		_ = monitor.Send(&networkservice.ConnectionEvent{
-			Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
-			Connections: connections,
-		})
+		sub.enqueue(m.initialStateTransfer(selector))
 	})
-	select {
-	case <-srv.Context().Done():
-	case <-m.ctx.Done():
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return nil
+		case <-srv.Context().Done():
+			return nil
+		case <-sub.ctx.Done():
+			if srv.Context().Err() != nil {
+				return nil
+			}
+			return status.Errorf(codes.ResourceExhausted, "events queue overflow: %d events", m.queueSize)
+		case event := <-sub.queue:
+			if err := sub.filter.Send(event); err != nil {
+				log.FromContext(m.ctx).Errorf("Error sending event: %+v: %+v", event, err)
+				continue
+			}
+			atomic.AddUint64(&sub.sent, 1)
+		}
 	}
-	return nil
+}
+
+// Lag - returns lag counters for all active subscribers
+func (m *monitorServer) Lag() (rv []*SubscriberLag) {
+	<-m.executor.AsyncExec(func() {
+		for _, sub := range m.subscribers {
+			rv = append(rv, sub.lag())
+		}
+	})
+	return rv
 }
 
 func (m *monitorServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -94,9 +125,7 @@ func (m *monitorServer) Request(ctx context.Context, request *networkservice.Net
 				Type:        networkservice.ConnectionEventType_UPDATE,
 				Connections: map[string]*networkservice.Connection{eventConn.GetId(): eventConn},
 			}
-			if sendErr := m.send(ctx, event); sendErr != nil {
-				log.FromContext(ctx).Errorf("Error during sending event: %v", sendErr)
-			}
+			m.send(ctx, event)
 		})
 	}
 	return conn, err
@@ -114,27 +143,47 @@ func (m *monitorServer) Close(ctx context.Context, conn *networkservice.Connecti
 			Type:        networkservice.ConnectionEventType_DELETE,
 			Connections: map[string]*networkservice.Connection{eventConn.GetId(): eventConn},
 		}
-		if err := m.send(ctx, event); err != nil {
-			log.FromContext(ctx).Errorf("Error during sending event: %v", err)
-		}
+		m.send(ctx, event)
 	})
 	return &empty.Empty{}, closeErr
 }
 
-// send - perform a send to clients.
-func (m *monitorServer) send(ctx context.Context, event *networkservice.ConnectionEvent) (err error) {
-	newMonitors := []networkservice.MonitorConnection_MonitorConnectionsServer{}
-	for _, filter := range m.monitors {
-		select {
-		case <-filter.Context().Done():
-		default:
-			if err = filter.Send(event.Clone()); err != nil {
-				log.FromContext(ctx).Errorf("Error sending event: %+v: %+v", event, err)
+// send - enqueue event to the subscribers, subscribers with the full queue are handled according to the overflowPolicy
+func (m *monitorServer) send(ctx context.Context, event *networkservice.ConnectionEvent) {
+	newSubscribers := []*subscriber{}
+	for _, sub := range m.subscribers {
+		if sub.ctx.Err() != nil {
+			continue
+		}
+		if !sub.enqueue(event.Clone()) {
+			switch m.overflowPolicy {
+			case Disconnect:
+				log.FromContext(ctx).Warnf("Disconnecting subscriber on events queue overflow: %+v", sub.filter.selector)
+				sub.cancel()
+				continue
+			default:
+				sub.drain()
+				atomic.AddUint64(&sub.dropped, 1)
+				atomic.AddUint64(&sub.resyncs, 1)
+				sub.enqueue(m.initialStateTransfer(sub.filter.selector))
 			}
-			newMonitors = append(newMonitors, filter)
 		}
+		newSubscribers = append(newSubscribers, sub)
 	}
 
-	m.monitors = newMonitors
-	return err
+	m.subscribers = newSubscribers
+}
+
+// initialStateTransfer - returns INITIAL_STATE_TRANSFER event with all the connections available for the selector
+func (m *monitorServer) initialStateTransfer(selector *networkservice.MonitorScopeSelector) *networkservice.ConnectionEvent {
+	connections := make(map[string]*networkservice.Connection)
+	for _, ps := range selector.PathSegments {
+		if conn, ok := m.connections[ps.GetId()]; ok {
+			connections[ps.GetId()] = conn.Clone()
+		}
+	}
+	return &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: connections,
+	}
 }
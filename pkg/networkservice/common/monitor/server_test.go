@@ -18,12 +18,17 @@ package monitor_test
 
 import (
 	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
@@ -105,3 +110,145 @@ func TestMonitor(t *testing.T) {
 		assert.Equal(t, segmentName, event.GetConnections()[segmentName].GetPath().GetPathSegments()[0].GetName())
 	}
 }
+
+type blockingMonitorServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	unblockCh chan struct{}
+	eventCh   chan *networkservice.ConnectionEvent
+}
+
+func (s *blockingMonitorServer) Send(event *networkservice.ConnectionEvent) error {
+	<-s.unblockCh
+	s.eventCh <- event
+	return nil
+}
+
+func (s *blockingMonitorServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestMonitor_SlowSubscriber(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var monitorServer networkservice.MonitorConnectionServer
+	server := monitor.NewServer(ctx, &monitorServer, monitor.WithQueueSize(2))
+	monitorClient := adapters.NewMonitorServerToClient(monitorServer)
+
+	selector := &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Id: "id"}},
+	}
+
+	slowSrv := &blockingMonitorServer{
+		ctx:       ctx,
+		unblockCh: make(chan struct{}),
+		eventCh:   make(chan *networkservice.ConnectionEvent, 100),
+	}
+	slowErrCh := make(chan error, 1)
+	go func() {
+		slowErrCh <- monitorServer.MonitorConnections(selector, slowSrv)
+	}()
+
+	receiver, err := monitorClient.MonitorConnections(ctx, selector)
+	require.NoError(t, err)
+	event, err := receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+
+	// Slow subscriber doesn't block the others
+	for i := 0; i < 10; i++ {
+		_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:      "id",
+				Context: &networkservice.ConnectionContext{ExtraContext: map[string]string{"i": strconv.Itoa(i)}},
+				Path: &networkservice.Path{
+					PathSegments: []*networkservice.PathSegment{{Id: "id"}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		event, err = receiver.Recv()
+		require.NoError(t, err)
+		require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+		require.Equal(t, strconv.Itoa(i), event.GetConnections()["id"].GetContext().GetExtraContext()["i"])
+	}
+
+	lagReporter, ok := monitorServer.(monitor.LagReporter)
+	require.True(t, ok)
+
+	var slowLag *monitor.SubscriberLag
+	for _, lag := range lagReporter.Lag() {
+		if lag.Dropped > 0 {
+			slowLag = lag
+		}
+	}
+	require.NotNil(t, slowLag)
+	require.Greater(t, slowLag.Resyncs, uint64(0))
+
+	// Slow subscriber is resynced with the actual state
+	close(slowSrv.unblockCh)
+	var resynced bool
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case event = <-slowSrv.eventCh:
+				if event.GetType() == networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER && len(event.GetConnections()) > 0 {
+					resynced = true
+				}
+			default:
+				return resynced && event.GetConnections()["id"].GetContext().GetExtraContext()["i"] == "9"
+			}
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-slowErrCh)
+}
+
+func TestMonitor_SlowSubscriberDisconnect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var monitorServer networkservice.MonitorConnectionServer
+	server := monitor.NewServer(ctx, &monitorServer,
+		monitor.WithQueueSize(1),
+		monitor.WithOverflowPolicy(monitor.Disconnect))
+
+	slowSrv := &blockingMonitorServer{
+		ctx:       ctx,
+		unblockCh: make(chan struct{}),
+		eventCh:   make(chan *networkservice.ConnectionEvent, 100),
+	}
+	slowErrCh := make(chan error, 1)
+	go func() {
+		slowErrCh <- monitorServer.MonitorConnections(&networkservice.MonitorScopeSelector{
+			PathSegments: []*networkservice.PathSegment{{Id: "id"}},
+		}, slowSrv)
+	}()
+
+	for i := 0; i < 3; i++ {
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: "id",
+				Path: &networkservice.Path{
+					PathSegments: []*networkservice.PathSegment{{Id: "id"}},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+	close(slowSrv.unblockCh)
+
+	select {
+	case err := <-slowErrCh:
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	case <-time.After(time.Second):
+		require.FailNow(t, "slow subscriber is not disconnected")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// SubscriberLag - lag counters of a single MonitorConnections subscriber
+type SubscriberLag struct {
+	// Selector - subscriber selector
+	Selector *networkservice.MonitorScopeSelector
+	// Queued - number of events queued but not yet sent to the subscriber
+	Queued int
+	// Sent - number of events sent to the subscriber
+	Sent uint64
+	// Dropped - number of events dropped because of the subscriber queue overflow
+	Dropped uint64
+	// Resyncs - number of INITIAL_STATE_TRANSFER events sent instead of the dropped ones
+	Resyncs uint64
+}
+
+// LagReporter - is implemented by the networkservice.MonitorConnectionServer created with NewServer
+type LagReporter interface {
+	// Lag - returns lag counters for all active subscribers
+	Lag() []*SubscriberLag
+}
+
+type subscriber struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	filter *monitorFilter
+	queue  chan *networkservice.ConnectionEvent
+
+	sent    uint64
+	dropped uint64
+	resyncs uint64
+}
+
+func newSubscriber(ctx context.Context, queueSize int, filter *monitorFilter) *subscriber {
+	ctx, cancel := context.WithCancel(ctx)
+	return &subscriber{
+		ctx:    ctx,
+		cancel: cancel,
+		filter: filter,
+		queue:  make(chan *networkservice.ConnectionEvent, queueSize),
+	}
+}
+
+// enqueue - tries to enqueue event without blocking, returns false if the queue is full
+func (s *subscriber) enqueue(event *networkservice.ConnectionEvent) bool {
+	select {
+	case s.queue <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// drain - drops all queued events
+func (s *subscriber) drain() {
+	for {
+		select {
+		case <-s.queue:
+			atomic.AddUint64(&s.dropped, 1)
+		default:
+			return
+		}
+	}
+}
+
+func (s *subscriber) lag() *SubscriberLag {
+	return &SubscriberLag{
+		Selector: s.filter.selector,
+		Queued:   len(s.queue),
+		Sent:     atomic.LoadUint64(&s.sent),
+		Dropped:  atomic.LoadUint64(&s.dropped),
+		Resyncs:  atomic.LoadUint64(&s.resyncs),
+	}
+}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 	"github.com/networkservicemesh/sdk/pkg/tools/serializectx"
 )
 
@@ -96,6 +97,7 @@ func (t *refreshClient) startTimer(ctx context.Context, connectionID string, req
 	duration := time.Duration(float64(time.Until(expireTime)) * scale)
 	req := request.Clone()
 	exec := serializectx.GetExecutor(ctx, connectionID)
+	m := metrics.FromContext(ctx)
 
 	var timer *time.Timer
 	timer = time.AfterFunc(duration, func() {
@@ -122,6 +124,8 @@ func (t *refreshClient) startTimer(ctx context.Context, connectionID string, req
 
 			if err == nil && rv != nil {
 				req.Connection = rv
+			} else if err != nil {
+				m.RefreshFailure(req.GetConnection().GetNetworkService())
 			}
 
 			t.startTimer(ctx, connectionID, req, opts)
@@ -18,59 +18,23 @@ package roundrobin
 
 import (
 	"context"
-	"net/url"
-
-	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
-
-	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
 
-	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/selectendpoint"
 )
 
-type selectEndpointServer struct {
-	selector *roundRobinSelector
-}
-
-// NewServer - provides a NetworkServiceServer chain element that round robins among candidates provided by
-// discover.Candidate(ctx) in the context.
+// NewServer - creates a new NetworkServiceServer chain element selecting endpoints in the round robin order
 func NewServer() networkservice.NetworkServiceServer {
-	return &selectEndpointServer{
-		selector: newRoundRobinSelector(),
-	}
+	return selectendpoint.NewServer(NewSelector())
 }
 
-func (s *selectEndpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	if clienturlctx.ClientURL(ctx) != nil {
-		return next.Server(ctx).Request(ctx, request)
-	}
-	candidates := discover.Candidates(ctx)
-
-	for i := 0; i < len(candidates.Endpoints); i++ {
-		endpoint := s.selector.selectEndpoint(candidates.NetworkService, candidates.Endpoints)
-		if endpoint == nil {
-			return nil, errors.Errorf("failed to find endpoint for Network Service: %v %v", candidates.NetworkService, candidates.Endpoints)
-		}
-		u, err := url.Parse(endpoint.Url)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-		ctx = clienturlctx.WithClientURL(ctx, u)
-		request.GetConnection().NetworkServiceEndpointName = endpoint.Name
-		resp, err := next.Server(ctx).Request(ctx, request)
-		if err == nil {
-			return resp, err
-		}
-	}
-	return nil, errors.Errorf("all candidates %#v fail", candidates)
+// NewSelector - returns a new round robin selectendpoint.Selector
+func NewSelector() selectendpoint.Selector {
+	return newRoundRobinSelector()
 }
 
-func (s *selectEndpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	if clienturlctx.ClientURL(ctx) != nil {
-		return next.Server(ctx).Close(ctx, conn)
-	}
-	return nil, errors.Errorf("passed incorrect connection: %+v", conn)
+func (rr *roundRobinSelector) Select(_ context.Context, ns *registry.NetworkService, nses []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return rr.selectEndpoint(ns, nses)
 }
@@ -0,0 +1,80 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectendpoint
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type firstHealthySelector struct {
+	cooldown time.Duration
+
+	sync.Mutex
+	failedAt map[string]time.Time
+}
+
+// NewFirstHealthySelector - returns a new Selector choosing the first healthy endpoint in the candidates order.
+//                           Endpoint is healthy if it is not expired and no Request to it has failed in the last
+//                           cooldown period. If there are no healthy endpoints, the one failed earliest is selected.
+func NewFirstHealthySelector(cooldown time.Duration) Selector {
+	return &firstHealthySelector{
+		cooldown: cooldown,
+		failedAt: make(map[string]time.Time),
+	}
+}
+
+func (s *firstHealthySelector) Select(ctx context.Context, _ *registry.NetworkService, nses []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	now := clock.FromContext(ctx).Now()
+
+	s.Lock()
+	defer s.Unlock()
+
+	var fallback *registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if expirationTime := nse.GetExpirationTime(); expirationTime != nil && !now.Before(expirationTime.AsTime()) {
+			continue
+		}
+		failedAt, ok := s.failedAt[nse.GetName()]
+		if !ok || now.Sub(failedAt) >= s.cooldown {
+			return nse
+		}
+		if fallback == nil || failedAt.Before(s.failedAt[fallback.GetName()]) {
+			fallback = nse
+		}
+	}
+	return fallback
+}
+
+func (s *firstHealthySelector) Selected(ctx context.Context, nse *registry.NetworkServiceEndpoint, _ *networkservice.Connection, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err != nil {
+		s.failedAt[nse.GetName()] = clock.FromContext(ctx).Now()
+	} else {
+		delete(s.failedAt, nse.GetName())
+	}
+}
+
+func (s *firstHealthySelector) Closed(_ context.Context, _ *networkservice.Connection) {}
@@ -0,0 +1,86 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectendpoint
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type leastActiveSelector struct {
+	sync.Mutex
+	nseByConnID  map[string]string
+	activeByName map[string]int
+}
+
+// NewLeastActiveSelector - returns a new Selector choosing the endpoint with the least number of active connections
+//                          established through it. Ties are resolved in the candidates order.
+func NewLeastActiveSelector() Selector {
+	return &leastActiveSelector{
+		nseByConnID:  make(map[string]string),
+		activeByName: make(map[string]int),
+	}
+}
+
+func (s *leastActiveSelector) Select(_ context.Context, _ *registry.NetworkService, nses []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	s.Lock()
+	defer s.Unlock()
+
+	var selected *registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if selected == nil || s.activeByName[nse.GetName()] < s.activeByName[selected.GetName()] {
+			selected = nse
+		}
+	}
+	return selected
+}
+
+func (s *leastActiveSelector) Selected(_ context.Context, nse *registry.NetworkServiceEndpoint, conn *networkservice.Connection, err error) {
+	if err != nil {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.release(conn.GetId())
+
+	s.nseByConnID[conn.GetId()] = nse.GetName()
+	s.activeByName[nse.GetName()]++
+}
+
+func (s *leastActiveSelector) Closed(_ context.Context, conn *networkservice.Connection) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.release(conn.GetId())
+}
+
+func (s *leastActiveSelector) release(connID string) {
+	nseName, ok := s.nseByConnID[connID]
+	if !ok {
+		return
+	}
+	delete(s.nseByConnID, connID)
+
+	if s.activeByName[nseName]--; s.activeByName[nseName] <= 0 {
+		delete(s.activeByName, nseName)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectendpoint
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type randomSelector struct {
+	sync.Mutex
+	rand *rand.Rand
+}
+
+// NewRandomSelector - returns a new Selector choosing a random endpoint. Selections sequence is fully defined by the
+//                     seed, so the same seed can be used to reproduce it.
+func NewRandomSelector(seed int64) Selector {
+	return &randomSelector{
+		// #nosec
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *randomSelector) Select(_ context.Context, _ *registry.NetworkService, nses []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if len(nses) == 0 {
+		return nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return nses[s.rand.Intn(len(nses))]
+}
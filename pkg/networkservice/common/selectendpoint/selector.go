@@ -0,0 +1,38 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectendpoint
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Selector - endpoint selection strategy
+type Selector interface {
+	// Select - returns one of the nses to use for the ns, or nil if there is no suitable endpoint
+	Select(ctx context.Context, ns *registry.NetworkService, nses []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint
+}
+
+// Observer - optional interface for the Selector that needs to be informed about the selection results
+type Observer interface {
+	// Selected - is called after the Request to the selected nse has returned
+	Selected(ctx context.Context, nse *registry.NetworkServiceEndpoint, conn *networkservice.Connection, err error)
+	// Closed - is called after the conn has been closed
+	Closed(ctx context.Context, conn *networkservice.Connection)
+}
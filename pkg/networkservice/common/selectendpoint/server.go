@@ -0,0 +1,104 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selectendpoint provides a NetworkServiceServer chain element that selects an endpoint from the
+// candidates found by discover using a pluggable Selector
+package selectendpoint
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+)
+
+type selectEndpointServer struct {
+	selector Selector
+}
+
+// NewServer - creates a new NetworkServiceServer chain element selecting endpoints from discover.Candidates(ctx)
+//             with the given selector
+func NewServer(selector Selector) networkservice.NetworkServiceServer {
+	if selector == nil {
+		panic("selector cannot be nil")
+	}
+	return &selectEndpointServer{
+		selector: selector,
+	}
+}
+
+func (s *selectEndpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if clienturlctx.ClientURL(ctx) != nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	candidates := discover.Candidates(ctx)
+
+	endpoints := candidates.Endpoints
+	for len(endpoints) > 0 {
+		endpoint := s.selector.Select(ctx, candidates.NetworkService, endpoints)
+		if endpoint == nil {
+			return nil, errors.Errorf("failed to find endpoint for Network Service: %v %v", candidates.NetworkService, candidates.Endpoints)
+		}
+		endpoints = exclude(endpoints, endpoint)
+
+		u, err := url.Parse(endpoint.Url)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		request.GetConnection().NetworkServiceEndpointName = endpoint.Name
+		resp, err := next.Server(ctx).Request(clienturlctx.WithClientURL(ctx, u), request)
+		if observer, ok := s.selector.(Observer); ok {
+			observer.Selected(ctx, endpoint, resp, err)
+		}
+		if err == nil {
+			return resp, err
+		}
+	}
+	return nil, errors.Errorf("all candidates %#v fail", candidates)
+}
+
+func (s *selectEndpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if clienturlctx.ClientURL(ctx) == nil {
+		return nil, errors.Errorf("passed incorrect connection: %+v", conn)
+	}
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	if observer, ok := s.selector.(Observer); ok {
+		observer.Closed(ctx, conn)
+	}
+	return rv, err
+}
+
+func exclude(endpoints []*registry.NetworkServiceEndpoint, endpoint *registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	var rv []*registry.NetworkServiceEndpoint
+	for _, nse := range endpoints {
+		if nse != endpoint {
+			rv = append(rv, nse)
+		}
+	}
+	if len(rv) == len(endpoints) {
+		// Selector has returned an endpoint not from the candidates list, we cannot safely retry with the others
+		return nil
+	}
+	return rv
+}
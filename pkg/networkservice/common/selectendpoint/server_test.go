@@ -0,0 +1,211 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectendpoint_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/selectendpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+const nsName = "ns"
+
+func testNSEs(weights ...string) []*registry.NetworkServiceEndpoint {
+	var nses []*registry.NetworkServiceEndpoint
+	for i, weight := range weights {
+		nse := &registry.NetworkServiceEndpoint{
+			Name:                "nse-" + string(rune('a'+i)),
+			Url:                 "tcp://nse-" + string(rune('a'+i)),
+			NetworkServiceNames: []string{nsName},
+		}
+		if weight != "" {
+			nse.NetworkServiceLabels = map[string]*registry.NetworkServiceLabels{
+				nsName: {
+					Labels: map[string]string{
+						selectendpoint.DefaultWeightLabel: weight,
+					},
+				},
+			}
+		}
+		nses = append(nses, nse)
+	}
+	return nses
+}
+
+func selectN(ctx context.Context, selector selectendpoint.Selector, nses []*registry.NetworkServiceEndpoint, n int) (names []string) {
+	for i := 0; i < n; i++ {
+		names = append(names, selector.Select(ctx, &registry.NetworkService{Name: nsName}, nses).GetName())
+	}
+	return names
+}
+
+type failingURLServer struct {
+	failing map[string]bool
+}
+
+func (s *failingURLServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.failing[clienturlctx.ClientURL(ctx).String()] {
+		return nil, errors.New("failed")
+	}
+	return request.GetConnection(), nil
+}
+
+func (s *failingURLServer) Close(context.Context, *networkservice.Connection) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
+
+func request(id string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             id,
+			NetworkService: nsName,
+		},
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	selector := selectendpoint.NewWeightedSelector(selectendpoint.DefaultWeightLabel)
+	nses := testNSEs("5", "1", "1")
+
+	require.Equal(t, []string{
+		"nse-a", "nse-a", "nse-b", "nse-a", "nse-c", "nse-a", "nse-a",
+	}, selectN(context.Background(), selector, nses, 7))
+}
+
+func TestWeightedSelector_ZeroWeight(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	selector := selectendpoint.NewWeightedSelector(selectendpoint.DefaultWeightLabel)
+
+	require.Equal(t, []string{"nse-b", "nse-c", "nse-b", "nse-c"},
+		selectN(context.Background(), selector, testNSEs("0", "", "invalid"), 4))
+	require.Equal(t, []string{"nse-a", "nse-b", "nse-a"},
+		selectN(context.Background(), selector, testNSEs("0", "0"), 3))
+}
+
+func TestRandomSelector_Seed(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	nses := testNSEs("", "", "", "", "")
+
+	expected := selectN(context.Background(), selectendpoint.NewRandomSelector(1), nses, 20)
+	require.Equal(t, expected, selectN(context.Background(), selectendpoint.NewRandomSelector(1), nses, 20))
+}
+
+func TestLeastActiveSelector(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	nses := testNSEs("", "", "")
+	server := next.NewNetworkServiceServer(
+		selectendpoint.NewServer(selectendpoint.NewLeastActiveSelector()),
+		&failingURLServer{},
+	)
+	ctx := discover.WithCandidates(context.Background(), nses, &registry.NetworkService{Name: nsName})
+
+	var conns []*networkservice.Connection
+	for _, id := range []string{"1", "2", "3", "4"} {
+		conn, err := server.Request(ctx, request(id))
+		require.NoError(t, err)
+		conns = append(conns, conn.Clone())
+	}
+	require.Equal(t, "nse-a", conns[0].GetNetworkServiceEndpointName())
+	require.Equal(t, "nse-b", conns[1].GetNetworkServiceEndpointName())
+	require.Equal(t, "nse-c", conns[2].GetNetworkServiceEndpointName())
+	require.Equal(t, "nse-a", conns[3].GetNetworkServiceEndpointName())
+
+	u, err := url.Parse(nses[1].Url)
+	require.NoError(t, err)
+	_, err = server.Close(clienturlctx.WithClientURL(ctx, u), conns[1])
+	require.NoError(t, err)
+
+	conn, err := server.Request(ctx, request("5"))
+	require.NoError(t, err)
+	require.Equal(t, "nse-b", conn.GetNetworkServiceEndpointName())
+}
+
+func TestFirstHealthySelector(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	clockMock := clockmock.NewMock()
+	ctx := clock.WithClock(context.Background(), clockMock)
+
+	nses := testNSEs("", "", "")
+	failing := &failingURLServer{
+		failing: map[string]bool{
+			nses[0].Url: true,
+		},
+	}
+	server := next.NewNetworkServiceServer(
+		selectendpoint.NewServer(selectendpoint.NewFirstHealthySelector(time.Minute)),
+		failing,
+	)
+	ctx = discover.WithCandidates(ctx, nses, &registry.NetworkService{Name: nsName})
+
+	conn, err := server.Request(ctx, request("1"))
+	require.NoError(t, err)
+	require.Equal(t, "nse-b", conn.GetNetworkServiceEndpointName())
+
+	// nse-a has recently failed, so it is skipped without even trying
+	failing.failing = nil
+	conn, err = server.Request(ctx, request("2"))
+	require.NoError(t, err)
+	require.Equal(t, "nse-b", conn.GetNetworkServiceEndpointName())
+
+	clockMock.Add(time.Minute)
+
+	conn, err = server.Request(ctx, request("3"))
+	require.NoError(t, err)
+	require.Equal(t, "nse-a", conn.GetNetworkServiceEndpointName())
+}
+
+func TestSelectEndpointServer_AllCandidatesFail(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	nses := testNSEs("", "", "")
+	failing := &failingURLServer{
+		failing: make(map[string]bool),
+	}
+	for _, nse := range nses {
+		failing.failing[nse.Url] = true
+	}
+	server := next.NewNetworkServiceServer(
+		selectendpoint.NewServer(selectendpoint.NewRandomSelector(0)),
+		failing,
+	)
+	ctx := discover.WithCandidates(context.Background(), nses, &registry.NetworkService{Name: nsName})
+
+	_, err := server.Request(ctx, request("1"))
+	require.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectendpoint
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// DefaultWeightLabel - default NSE label to read the endpoint weight from
+const DefaultWeightLabel = "weight"
+
+type weightedSelector struct {
+	weightLabel string
+
+	sync.Mutex
+	currentWeights map[string]map[string]int
+}
+
+// NewWeightedSelector - returns a new smooth weighted round robin Selector. Endpoint weight is read from the
+//                       weightLabel label of the NSE for the network service. Endpoints with missing or malformed
+//                       weight have weight 1, endpoints with weight 0 are selected only if there are no others.
+func NewWeightedSelector(weightLabel string) Selector {
+	return &weightedSelector{
+		weightLabel:    weightLabel,
+		currentWeights: make(map[string]map[string]int),
+	}
+}
+
+func (s *weightedSelector) Select(_ context.Context, ns *registry.NetworkService, nses []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if len(nses) == 0 {
+		return nil
+	}
+
+	weights := make([]int, len(nses))
+	var total int
+	for i, nse := range nses {
+		weights[i] = s.weight(ns.GetName(), nse)
+		total += weights[i]
+	}
+	if total == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = len(weights)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// Entries for the endpoints that are not candidates anymore are dropped here
+	oldWeights := s.currentWeights[ns.GetName()]
+	currentWeights := make(map[string]int, len(nses))
+
+	var selected *registry.NetworkServiceEndpoint
+	for i, nse := range nses {
+		currentWeights[nse.GetName()] = oldWeights[nse.GetName()] + weights[i]
+		if selected == nil || currentWeights[nse.GetName()] > currentWeights[selected.GetName()] {
+			selected = nse
+		}
+	}
+	currentWeights[selected.GetName()] -= total
+
+	s.currentWeights[ns.GetName()] = currentWeights
+
+	return selected
+}
+
+func (s *weightedSelector) weight(nsName string, nse *registry.NetworkServiceEndpoint) int {
+	value, ok := nse.GetNetworkServiceLabels()[nsName].GetLabels()[s.weightLabel]
+	if !ok {
+		return 1
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 0 {
+		return 1
+	}
+	return weight
+}
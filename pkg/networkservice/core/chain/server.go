@@ -23,9 +23,11 @@ package chain
 import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/metrics"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/setlogoption"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	metricstools "github.com/networkservicemesh/sdk/pkg/tools/metrics"
 )
 
 // NewNetworkServiceServer - chains together a list of networkservice.Servers with tracing
@@ -33,10 +35,15 @@ func NewNetworkServiceServer(servers ...networkservice.NetworkServiceServer) net
 	return next.NewWrappedNetworkServiceServer(trace.NewNetworkServiceServer, servers...)
 }
 
-// NewNamedNetworkServiceServer - chains together a list of networkservice.Servers with tracing and name log option
+// NewNamedNetworkServiceServer - chains together a list of networkservice.Servers with tracing and name log option.
+// If element metrics are enabled with metrics.EnableElementMetrics, metrics are collected for every chain element.
 func NewNamedNetworkServiceServer(name string, servers ...networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+	var wrapper next.ServerWrapper = trace.NewNetworkServiceServer
+	if metricstools.IsElementMetricsEnabled() {
+		wrapper = metrics.Wrap(wrapper)
+	}
 	return next.NewNetworkServiceServer(
 		setlogoption.NewServer(map[string]string{"name": name}),
-		next.NewWrappedNetworkServiceServer(trace.NewNetworkServiceServer, servers...),
+		next.NewWrappedNetworkServiceServer(wrapper, servers...),
 	)
 }
@@ -22,10 +22,12 @@ package trace
 import (
 	"context"
 
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/jsonlogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/spanlogger"
 )
@@ -34,6 +36,10 @@ type contextKeyType string
 
 const (
 	traceInfoKey contextKeyType = "ConnectionInfo"
+
+	connectionIDKey   = "connection_id"
+	pathIndexKey      = "path_index"
+	networkServiceKey = "network_service"
 )
 
 // ConnectionInfo - struct, containing string representations of request and response, used for tracing.
@@ -45,18 +51,30 @@ type traceInfo struct {
 }
 
 // withLog - provides corresponding logger in context
-func withLog(parent context.Context, operation string) (c context.Context, f func()) {
+func withLog(parent context.Context, operation string, conn *networkservice.Connection) (c context.Context, f func()) {
 	if parent == nil {
 		panic("cannot create context from nil parent")
 	}
 
+	if log.IsJSONFormatEnabled() {
+		parent = log.WithFields(parent, map[string]interface{}{
+			connectionIDKey:   conn.GetId(),
+			pathIndexKey:      conn.GetPath().GetIndex(),
+			networkServiceKey: conn.GetNetworkService(),
+		})
+	}
+
 	// Update outgoing grpc context
 	parent = grpcutils.PassTraceToOutgoing(parent)
 
 	if grpcTraceState := grpcutils.TraceFromContext(parent); (grpcTraceState == grpcutils.TraceOn) ||
 		(grpcTraceState == grpcutils.TraceUndefined && log.IsTracingEnabled()) {
 		ctx, sLogger, span, sFinish := spanlogger.FromContext(parent, operation)
-		ctx, lLogger, lFinish := logruslogger.FromSpan(ctx, span, operation)
+		fromSpan := logruslogger.FromSpan
+		if log.IsJSONFormatEnabled() {
+			fromSpan = jsonlogger.FromSpan
+		}
+		ctx, lLogger, lFinish := fromSpan(ctx, span, operation)
 		return withTrace(log.WithLog(ctx, sLogger, lLogger)), func() {
 			sFinish()
 			lFinish()
@@ -47,7 +47,7 @@ func NewNetworkServiceServer(traced networkservice.NetworkServiceServer) network
 func (t *beginTraceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	// Create a new logger
 	operation := typeutils.GetFuncName(t.traced, "Request")
-	ctx, finish := withLog(ctx, operation)
+	ctx, finish := withLog(ctx, operation, request.GetConnection())
 	defer finish()
 
 	logRequest(ctx, request)
@@ -72,7 +72,7 @@ func (t *beginTraceServer) Request(ctx context.Context, request *networkservice.
 func (t *beginTraceServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	// Create a new logger
 	operation := typeutils.GetFuncName(t.traced, "Close")
-	ctx, finish := withLog(ctx, operation)
+	ctx, finish := withLog(ctx, operation, conn)
 	defer finish()
 
 	logRequest(ctx, conn)
@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package point2pointipam
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+)
+
+const (
+	// SecondarySrcIPAddrKey - ConnectionContext.ExtraContext key for the secondary source address allocated in
+	// dual-stack mode
+	SecondarySrcIPAddrKey = "secondarySrcIpAddr"
+	// SecondaryDstIPAddrKey - ConnectionContext.ExtraContext key for the secondary destination address allocated in
+	// dual-stack mode
+	SecondaryDstIPAddrKey = "secondaryDstIpAddr"
+)
+
+// Option is an option for the IPAM server
+type Option func(s *ipamServer)
+
+// WithStore sets store for the allocations. On the first Request or Close, IPAM server restores its state from the
+// store, so the same addresses are returned for the known connection IDs. Restored allocations not claimed by their
+// connections are released after the connection expiration.
+func WithStore(store Store) Option {
+	return func(s *ipamServer) {
+		s.store = store
+	}
+}
+
+// WithDualStack enables dual-stack mode: a pair of addresses is allocated from each of IPv4 and IPv6 prefixes. IPv4
+// addresses are set to IPContext.SrcIpAddr, IPContext.DstIpAddr, IPv6 addresses are set to ConnectionContext.ExtraContext
+// under SecondarySrcIPAddrKey, SecondaryDstIPAddrKey. If there is only one IP family configured or requested with the
+// extra prefix requests, only its addresses are allocated as primary ones. Routes are added for all allocated addresses.
+func WithDualStack() Option {
+	return func(s *ipamServer) {
+		s.dualStack = true
+	}
+}
+
+// WithAllocationStrategy sets strategy for picking the free addresses, default is ippool.LowestStrategy
+func WithAllocationStrategy(strategy ippool.AllocationStrategy) Option {
+	return func(s *ipamServer) {
+		s.strategy = strategy
+	}
+}
+
+// WithQuarantine sets period for which the released addresses are not allocated again, so the stale ARP entries and
+// routes on the other peers have time to expire. Default is 0 meaning no quarantine.
+func WithQuarantine(period time.Duration) Option {
+	return func(s *ipamServer) {
+		s.quarantinePeriod = period
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
@@ -28,34 +29,65 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type ipamServer struct {
+	ctx      context.Context
 	ipPools  []*ippool.IPPool
 	prefixes []*net.IPNet
 	once     sync.Once
 	initErr  error
+
+	store      Store
+	restored   map[string]*connectionInfo
+	restoredMu sync.Mutex
+
+	dualStack bool
+
+	strategy         ippool.AllocationStrategy
+	quarantinePeriod time.Duration
 }
 
 type connectionInfo struct {
 	ipPool  *ippool.IPPool
 	srcAddr string
 	dstAddr string
+
+	// secondary - addresses allocated from the other IP family in dual-stack mode
+	secondary *connectionInfo
 }
 
 func (i *connectionInfo) shouldUpdate(exclude *ippool.IPPool) bool {
 	srcIP, _, srcErr := net.ParseCIDR(i.srcAddr)
 	dstIP, _, dstErr := net.ParseCIDR(i.dstAddr)
 
-	return srcErr == nil && exclude.ContainsString(srcIP.String()) || dstErr == nil && exclude.ContainsString(dstIP.String())
+	if srcErr == nil && exclude.ContainsString(srcIP.String()) || dstErr == nil && exclude.ContainsString(dstIP.String()) {
+		return true
+	}
+	return i.secondary != nil && i.secondary.shouldUpdate(exclude)
 }
 
 // NewServer - creates a new NetworkServiceServer chain element that implements IPAM service.
 func NewServer(prefixes ...*net.IPNet) networkservice.NetworkServiceServer {
-	return &ipamServer{
+	return NewServerWithOptions(context.Background(), prefixes)
+}
+
+// NewServerWithOptions - creates a new NetworkServiceServer chain element that implements IPAM service configured with
+// the options. ctx is the server lifecycle context: clock is taken from it, timers releasing the restored allocations
+// are stopped on ctx.Done().
+func NewServerWithOptions(ctx context.Context, prefixes []*net.IPNet, options ...Option) networkservice.NetworkServiceServer {
+	s := &ipamServer{
+		ctx:      ctx,
 		prefixes: prefixes,
+		restored: make(map[string]*connectionInfo),
 	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
 }
 
 func (s *ipamServer) init() {
@@ -69,10 +101,131 @@ func (s *ipamServer) init() {
 			s.initErr = errors.Errorf("prefix must not be nil: %+v", s.prefixes)
 			return
 		}
-		s.ipPools = append(s.ipPools, ippool.NewWithNet(prefix))
+		ipPool := ippool.NewWithNet(prefix)
+		ipPool.SetAllocationStrategy(s.strategy)
+		ipPool.SetQuarantine(s.quarantinePeriod, clock.FromContext(s.ctx))
+		s.ipPools = append(s.ipPools, ipPool)
+	}
+
+	if s.store != nil {
+		s.initErr = s.restore()
 	}
 }
 
+// restore - restores allocations from the store, marking their addresses as used
+func (s *ipamServer) restore() error {
+	allocations, err := s.store.Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to restore IPAM state")
+	}
+
+	s.restoredMu.Lock()
+	defer s.restoredMu.Unlock()
+
+	clk := clock.FromContext(s.ctx)
+	var timers []clock.Timer
+	for _, allocation := range allocations {
+		connInfo := s.restoredConnInfo(allocation)
+		expires := restoredExpires(allocation, clk.Now())
+		if connInfo == nil || (!expires.IsZero() && !clk.Now().Before(expires)) {
+			_ = s.store.Delete(allocation.ConnectionID)
+			continue
+		}
+
+		for info := connInfo; info != nil; info = info.secondary {
+			info.ipPool.ExcludeString(info.srcAddr)
+			info.ipPool.ExcludeString(info.dstAddr)
+		}
+		s.restored[allocation.ConnectionID] = connInfo
+
+		if !expires.IsZero() {
+			connID := allocation.ConnectionID
+			timers = append(timers, clk.AfterFunc(clk.Until(expires), func() {
+				s.expireRestored(connID, connInfo)
+			}))
+		}
+	}
+
+	if len(timers) > 0 && s.ctx.Done() != nil {
+		go func() {
+			<-s.ctx.Done()
+			for _, timer := range timers {
+				timer.Stop()
+			}
+		}()
+	}
+	return nil
+}
+
+// restoredExpires - allocations are stored only on creation, so the stored expiration time is the one of the first
+// Request. Restored allocation is kept for at least one more connection lifetime to give its connection a chance to
+// refresh.
+func restoredExpires(allocation *Allocation, now time.Time) time.Time {
+	if allocation.Expires.IsZero() || allocation.Allocated.IsZero() {
+		return allocation.Expires
+	}
+	if expires := now.Add(allocation.Expires.Sub(allocation.Allocated)); expires.After(allocation.Expires) {
+		return expires
+	}
+	return allocation.Expires
+}
+
+// restoredConnInfo - returns connection info for the allocation, or nil if it doesn't fit into the prefixes
+func (s *ipamServer) restoredConnInfo(allocation *Allocation) *connectionInfo {
+	connInfo := s.restoredAddrs(allocation.SrcAddr, allocation.DstAddr)
+	if connInfo == nil || allocation.SecondarySrcAddr == "" && allocation.SecondaryDstAddr == "" {
+		return connInfo
+	}
+	if connInfo.secondary = s.restoredAddrs(allocation.SecondarySrcAddr, allocation.SecondaryDstAddr); connInfo.secondary == nil {
+		return nil
+	}
+	return connInfo
+}
+
+func (s *ipamServer) restoredAddrs(srcAddr, dstAddr string) *connectionInfo {
+	srcIP, _, srcErr := net.ParseCIDR(srcAddr)
+	dstIP, _, dstErr := net.ParseCIDR(dstAddr)
+	if srcErr != nil || dstErr != nil {
+		return nil
+	}
+	for i, prefix := range s.prefixes {
+		if prefix.Contains(srcIP) && prefix.Contains(dstIP) {
+			return &connectionInfo{
+				ipPool:  s.ipPools[i],
+				srcAddr: srcAddr,
+				dstAddr: dstAddr,
+			}
+		}
+	}
+	return nil
+}
+
+// loadRestored - returns and forgets the restored connection info for the connection ID
+func (s *ipamServer) loadRestored(connID string) (*connectionInfo, bool) {
+	s.restoredMu.Lock()
+	defer s.restoredMu.Unlock()
+
+	connInfo, ok := s.restored[connID]
+	if ok {
+		delete(s.restored, connID)
+	}
+	return connInfo, ok
+}
+
+// expireRestored - releases the restored connection info if it is still not claimed by its connection
+func (s *ipamServer) expireRestored(connID string, connInfo *connectionInfo) {
+	s.restoredMu.Lock()
+	defer s.restoredMu.Unlock()
+
+	if s.restored[connID] != connInfo {
+		return
+	}
+	delete(s.restored, connID)
+
+	s.free(connInfo)
+	_ = s.store.Delete(connID)
+}
+
 func (s *ipamServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	s.once.Do(s.init)
 	if s.initErr != nil {
@@ -91,41 +244,96 @@ func (s *ipamServer) Request(ctx context.Context, request *networkservice.Networ
 	excludeIP4, excludeIP6 := exclude(ipContext.GetExcludedPrefixes()...)
 
 	connInfo, loaded := loadConnInfo(ctx)
+	var restored bool
+	if !loaded {
+		if connInfo, restored = s.loadRestored(conn.GetId()); restored {
+			storeConnInfo(ctx, connInfo)
+			loaded = true
+		}
+	}
 	var err error
 	if loaded && (connInfo.shouldUpdate(excludeIP4) || connInfo.shouldUpdate(excludeIP6)) {
 		// some of the existing addresses are excluded
-		deleteRoute(&ipContext.SrcRoutes, connInfo.dstAddr)
-		deleteRoute(&ipContext.DstRoutes, connInfo.srcAddr)
+		for info := connInfo; info != nil; info = info.secondary {
+			deleteRoute(&ipContext.SrcRoutes, info.dstAddr)
+			deleteRoute(&ipContext.DstRoutes, info.srcAddr)
+		}
+		delete(conn.GetContext().GetExtraContext(), SecondarySrcIPAddrKey)
+		delete(conn.GetContext().GetExtraContext(), SecondaryDstIPAddrKey)
 		s.free(connInfo)
 		loaded = false
 	}
 	if !loaded {
-		if connInfo, err = s.getP2PAddrs(excludeIP4, excludeIP6); err != nil {
+		if s.dualStack {
+			connInfo, err = s.getDualStackP2PAddrs(ipContext, excludeIP4, excludeIP6)
+		} else {
+			connInfo, err = s.getP2PAddrs(s.ipPools, excludeIP4, excludeIP6)
+		}
+		if err != nil {
 			return nil, err
 		}
 		storeConnInfo(ctx, connInfo)
 	}
 
 	ipContext.SrcIpAddr = connInfo.srcAddr
-	addRoute(&ipContext.SrcRoutes, connInfo.dstAddr)
-
 	ipContext.DstIpAddr = connInfo.dstAddr
-	addRoute(&ipContext.DstRoutes, connInfo.srcAddr)
+	if connInfo.secondary != nil {
+		if conn.GetContext().GetExtraContext() == nil {
+			conn.GetContext().ExtraContext = make(map[string]string)
+		}
+		conn.GetContext().GetExtraContext()[SecondarySrcIPAddrKey] = connInfo.secondary.srcAddr
+		conn.GetContext().GetExtraContext()[SecondaryDstIPAddrKey] = connInfo.secondary.dstAddr
+	}
+	for info := connInfo; info != nil; info = info.secondary {
+		addRoute(&ipContext.SrcRoutes, info.dstAddr)
+		addRoute(&ipContext.DstRoutes, info.srcAddr)
+	}
 
 	conn, err = next.Server(ctx).Request(ctx, request)
 	if err != nil {
-		if !loaded {
+		// restored connection is not established yet, so its addresses are released the same as the new ones
+		if !loaded || restored {
 			s.free(connInfo)
 		}
+		if restored {
+			s.deleteAllocation(ctx, request.GetConnection().GetId())
+		}
 		return nil, err
 	}
 
+	if !loaded {
+		s.storeAllocation(ctx, conn, connInfo)
+	}
+
 	return conn, nil
 }
 
-func (s *ipamServer) getP2PAddrs(excludeIP4, excludeIP6 *ippool.IPPool) (connInfo *connectionInfo, err error) {
+func (s *ipamServer) storeAllocation(ctx context.Context, conn *networkservice.Connection, connInfo *connectionInfo) {
+	if s.store == nil {
+		return
+	}
+
+	allocation := &Allocation{
+		ConnectionID: conn.GetId(),
+		SrcAddr:      connInfo.srcAddr,
+		DstAddr:      connInfo.dstAddr,
+	}
+	if connInfo.secondary != nil {
+		allocation.SecondarySrcAddr = connInfo.secondary.srcAddr
+		allocation.SecondaryDstAddr = connInfo.secondary.dstAddr
+	}
+	if expires := conn.GetCurrentPathSegment().GetExpires(); expires != nil {
+		allocation.Allocated = clock.FromContext(s.ctx).Now()
+		allocation.Expires = expires.AsTime()
+	}
+	if err := s.store.Store(allocation); err != nil {
+		log.FromContext(ctx).WithField("ipamServer", "Request").Errorf("Failed to store allocation: %s", err.Error())
+	}
+}
+
+func (s *ipamServer) getP2PAddrs(ipPools []*ippool.IPPool, excludeIP4, excludeIP6 *ippool.IPPool) (connInfo *connectionInfo, err error) {
 	var dstAddr, srcAddr *net.IPNet
-	for _, ipPool := range s.ipPools {
+	for _, ipPool := range ipPools {
 		if dstAddr, srcAddr, err = ipPool.PullP2PAddrs(excludeIP4, excludeIP6); err == nil {
 			return &connectionInfo{
 				ipPool:  ipPool,
@@ -137,6 +345,54 @@ func (s *ipamServer) getP2PAddrs(excludeIP4, excludeIP6 *ippool.IPPool) (connInf
 	return nil, err
 }
 
+// getDualStackP2PAddrs - allocates a pair of addresses from each IP family: the first one is primary, the other one is
+// secondary. If the request has extra prefix requests, only their IP families are allocated.
+func (s *ipamServer) getDualStackP2PAddrs(ipContext *networkservice.IPContext, excludeIP4, excludeIP6 *ippool.IPPool) (connInfo *connectionInfo, err error) {
+	requestedFamilies := make(map[networkservice.IpFamily_Family]bool)
+	for _, extraPrefixRequest := range ipContext.GetExtraPrefixRequest() {
+		requestedFamilies[extraPrefixRequest.GetAddrFamily().GetFamily()] = true
+	}
+
+	for _, family := range []networkservice.IpFamily_Family{networkservice.IpFamily_IPV4, networkservice.IpFamily_IPV6} {
+		if len(requestedFamilies) > 0 && !requestedFamilies[family] {
+			continue
+		}
+
+		ipPools := s.familyPools(family)
+		if len(ipPools) == 0 {
+			continue
+		}
+
+		var info *connectionInfo
+		if info, err = s.getP2PAddrs(ipPools, excludeIP4, excludeIP6); err != nil {
+			if connInfo != nil {
+				s.free(connInfo)
+			}
+			return nil, err
+		}
+
+		if connInfo == nil {
+			connInfo = info
+		} else {
+			connInfo.secondary = info
+		}
+	}
+
+	if connInfo == nil {
+		return nil, errors.Errorf("no prefixes for the requested IP families: %+v", s.prefixes)
+	}
+	return connInfo, nil
+}
+
+func (s *ipamServer) familyPools(family networkservice.IpFamily_Family) (ipPools []*ippool.IPPool) {
+	for i, prefix := range s.prefixes {
+		if isIPv4 := prefix.IP.To4() != nil; isIPv4 == (family == networkservice.IpFamily_IPV4) {
+			ipPools = append(ipPools, s.ipPools[i])
+		}
+	}
+	return ipPools
+}
+
 func deleteRoute(routes *[]*networkservice.Route, prefix string) {
 	for i, route := range *routes {
 		if route.Prefix == prefix {
@@ -163,14 +419,31 @@ func (s *ipamServer) Close(ctx context.Context, conn *networkservice.Connection)
 		return nil, s.initErr
 	}
 
-	if connInfo, ok := loadConnInfo(ctx); ok {
+	connInfo, ok := loadConnInfo(ctx)
+	if !ok {
+		connInfo, ok = s.loadRestored(conn.GetId())
+	}
+	if ok {
 		s.free(connInfo)
 	}
+	s.deleteAllocation(ctx, conn.GetId())
 
 	return next.Server(ctx).Close(ctx, conn)
 }
 
+func (s *ipamServer) deleteAllocation(ctx context.Context, connID string) {
+	if s.store == nil {
+		return
+	}
+
+	if err := s.store.Delete(connID); err != nil {
+		log.FromContext(ctx).WithField("ipamServer", "deleteAllocation").Errorf("Failed to delete allocation: %s", err.Error())
+	}
+}
+
 func (s *ipamServer) free(connInfo *connectionInfo) {
-	connInfo.ipPool.AddNetString(connInfo.srcAddr)
-	connInfo.ipPool.AddNetString(connInfo.dstAddr)
+	for info := connInfo; info != nil; info = info.secondary {
+		info.ipPool.ReleaseNetString(info.srcAddr)
+		info.ipPool.ReleaseNetString(info.dstAddr)
+	}
 }
@@ -19,9 +19,13 @@ package point2pointipam_test
 import (
 	"context"
 	"net"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
@@ -30,6 +34,9 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/point2pointipam"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
 )
 
 func newIpamServer(prefixes ...*net.IPNet) networkservice.NetworkServiceServer {
@@ -347,3 +354,362 @@ func TestRefreshNextError(t *testing.T) {
 	require.NoError(t, err)
 	validateConn(t, conn, "192.168.0.2/32", "192.168.0.3/32")
 }
+
+func newStoredRequest(connID string, expires time.Time) *networkservice.NetworkServiceRequest {
+	request := newRequest()
+	request.Connection.Id = connID
+	request.Connection.Path = &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Name:    "ipam",
+				Id:      connID,
+				Expires: timestamppb.New(expires),
+			},
+		},
+	}
+	return request
+}
+
+func TestStoreRestart(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	storePath := filepath.Join(t.TempDir(), "ipam.json")
+	newStoredIpamServer := func() networkservice.NetworkServiceServer {
+		return next.NewNetworkServiceServer(
+			updatepath.NewServer("ipam"),
+			metadata.NewServer(),
+			point2pointipam.NewServerWithOptions(context.Background(), []*net.IPNet{ipNet}, point2pointipam.WithStore(point2pointipam.NewFileStore(storePath))),
+		)
+	}
+
+	srv := newStoredIpamServer()
+
+	expires := time.Now().Add(time.Hour)
+	_, err = srv.Request(context.Background(), newStoredRequest("1", expires))
+	require.NoError(t, err)
+
+	conn2, err := srv.Request(context.Background(), newStoredRequest("2", expires))
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+
+	// Restart
+	srv = newStoredIpamServer()
+
+	conn3, err := srv.Request(context.Background(), newStoredRequest("3", expires))
+	require.NoError(t, err)
+	validateConn(t, conn3, "192.168.0.4/32", "192.168.0.5/32")
+
+	conn2, err = srv.Request(context.Background(), newStoredRequest("2", expires))
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+
+	_, err = srv.Close(context.Background(), conn2)
+	require.NoError(t, err)
+
+	// Restart
+	srv = newStoredIpamServer()
+
+	conn4, err := srv.Request(context.Background(), newStoredRequest("4", expires))
+	require.NoError(t, err)
+	validateConn(t, conn4, "192.168.0.2/32", "192.168.0.3/32")
+}
+
+func TestStoreExpiredAllocations(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	store := point2pointipam.NewFileStore(filepath.Join(t.TempDir(), "ipam.json"))
+	require.NoError(t, store.Store(&point2pointipam.Allocation{
+		ConnectionID: "expired",
+		SrcAddr:      "192.168.0.0/32",
+		DstAddr:      "192.168.0.1/32",
+		Expires:      time.Now().Add(-time.Second),
+	}))
+	require.NoError(t, store.Store(&point2pointipam.Allocation{
+		ConnectionID: "expiring",
+		SrcAddr:      "192.168.0.2/32",
+		DstAddr:      "192.168.0.3/32",
+		Expires:      time.Now().Add(100 * time.Millisecond),
+	}))
+
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(context.Background(), []*net.IPNet{ipNet}, point2pointipam.WithStore(store)),
+	)
+
+	expires := time.Now().Add(time.Hour)
+	conn, err := srv.Request(context.Background(), newStoredRequest("1", expires))
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.0/32", "192.168.0.1/32")
+
+	require.Eventually(t, func() bool {
+		allocations, loadErr := store.Load()
+		return loadErr == nil && len(allocations) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	conn, err = srv.Request(context.Background(), newStoredRequest("2", expires))
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.2/32", "192.168.0.3/32")
+}
+
+func TestStoreRestoredExpiration(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	clockMock := clockmock.NewMock()
+	ctx, cancel := context.WithCancel(clock.WithClock(context.Background(), clockMock))
+	defer cancel()
+
+	// Allocation was created an hour ago with 50 minutes lifetime and has never been stored again
+	now := clockMock.Now()
+	store := point2pointipam.NewFileStore(filepath.Join(t.TempDir(), "ipam.json"))
+	require.NoError(t, store.Store(&point2pointipam.Allocation{
+		ConnectionID: "restored",
+		SrcAddr:      "192.168.0.0/32",
+		DstAddr:      "192.168.0.1/32",
+		Allocated:    now.Add(-time.Hour),
+		Expires:      now.Add(-10 * time.Minute),
+	}))
+
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(ctx, []*net.IPNet{ipNet}, point2pointipam.WithStore(store)),
+	)
+
+	expires := now.Add(time.Hour)
+	conn, err := srv.Request(ctx, newStoredRequest("1", expires))
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.2/32", "192.168.0.3/32")
+
+	clockMock.Add(40 * time.Minute)
+
+	conn, err = srv.Request(ctx, newStoredRequest("2", expires))
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.4/32", "192.168.0.5/32")
+
+	clockMock.Add(10 * time.Minute)
+
+	require.Eventually(t, func() bool {
+		allocations, loadErr := store.Load()
+		return loadErr == nil && len(allocations) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	conn, err = srv.Request(ctx, newStoredRequest("3", expires))
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.0/32", "192.168.0.1/32")
+}
+
+func TestStoreRestoredNextError(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	store := point2pointipam.NewFileStore(filepath.Join(t.TempDir(), "ipam.json"))
+	require.NoError(t, store.Store(&point2pointipam.Allocation{
+		ConnectionID: "restored",
+		SrcAddr:      "192.168.0.0/32",
+		DstAddr:      "192.168.0.1/32",
+		Expires:      time.Now().Add(time.Hour),
+	}))
+
+	ipamServer := point2pointipam.NewServerWithOptions(context.Background(), []*net.IPNet{ipNet}, point2pointipam.WithStore(store))
+
+	expires := time.Now().Add(time.Hour)
+	_, err = next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		ipamServer,
+		injecterror.NewServer(),
+	).Request(context.Background(), newStoredRequest("restored", expires))
+	require.Error(t, err)
+
+	allocations, err := store.Load()
+	require.NoError(t, err)
+	require.Empty(t, allocations)
+
+	conn, err := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		ipamServer,
+	).Request(context.Background(), newStoredRequest("1", expires))
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.0/32", "192.168.0.1/32")
+}
+
+type countingStore struct {
+	store  point2pointipam.Store
+	stores int32
+}
+
+func (s *countingStore) Load() ([]*point2pointipam.Allocation, error) {
+	return s.store.Load()
+}
+
+func (s *countingStore) Store(allocation *point2pointipam.Allocation) error {
+	atomic.AddInt32(&s.stores, 1)
+	return s.store.Store(allocation)
+}
+
+func (s *countingStore) Delete(connectionID string) error {
+	return s.store.Delete(connectionID)
+}
+
+func TestStoreRefresh(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	store := &countingStore{
+		store: point2pointipam.NewFileStore(filepath.Join(t.TempDir(), "ipam.json")),
+	}
+
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(context.Background(), []*net.IPNet{ipNet}, point2pointipam.WithStore(store)),
+	)
+
+	request := newStoredRequest("1", time.Now().Add(time.Hour))
+	for i := 0; i < 3; i++ {
+		request.Connection, err = srv.Request(context.Background(), request.Clone())
+		require.NoError(t, err)
+		validateConn(t, request.Connection, "192.168.0.0/32", "192.168.0.1/32")
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&store.stores))
+}
+
+func newDualStackIpamServer(t *testing.T) networkservice.NetworkServiceServer {
+	_, ipNet4, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+	_, ipNet6, err := net.ParseCIDR("fe80::/64")
+	require.NoError(t, err)
+
+	return next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(context.Background(), []*net.IPNet{ipNet6, ipNet4}, point2pointipam.WithDualStack()),
+	)
+}
+
+func validateDualStackConn(t *testing.T, conn *networkservice.Connection, dst, src, secondaryDst, secondarySrc string) {
+	require.Equal(t, dst, conn.GetContext().GetIpContext().GetDstIpAddr())
+	require.Equal(t, src, conn.GetContext().GetIpContext().GetSrcIpAddr())
+	require.Equal(t, secondaryDst, conn.GetContext().GetExtraContext()[point2pointipam.SecondaryDstIPAddrKey])
+	require.Equal(t, secondarySrc, conn.GetContext().GetExtraContext()[point2pointipam.SecondarySrcIPAddrKey])
+
+	require.Equal(t, []*networkservice.Route{{Prefix: src}, {Prefix: secondarySrc}}, conn.GetContext().GetIpContext().GetDstRoutes())
+	require.Equal(t, []*networkservice.Route{{Prefix: dst}, {Prefix: secondaryDst}}, conn.GetContext().GetIpContext().GetSrcRoutes())
+}
+
+func TestDualStack(t *testing.T) {
+	srv := newDualStackIpamServer(t)
+
+	conn1, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateDualStackConn(t, conn1, "192.168.0.0/32", "192.168.0.1/32", "fe80::/128", "fe80::1/128")
+
+	conn2, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateDualStackConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32", "fe80::2/128", "fe80::3/128")
+
+	_, err = srv.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn3, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateDualStackConn(t, conn3, "192.168.0.0/32", "192.168.0.1/32", "fe80::/128", "fe80::1/128")
+
+	conn3, err = srv.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn3})
+	require.NoError(t, err)
+	validateDualStackConn(t, conn3, "192.168.0.0/32", "192.168.0.1/32", "fe80::/128", "fe80::1/128")
+}
+
+func TestDualStackExtraPrefixFamily(t *testing.T) {
+	srv := newDualStackIpamServer(t)
+
+	request := newRequest()
+	request.Connection.Context.IpContext.ExtraPrefixRequest = []*networkservice.ExtraPrefixRequest{
+		{
+			AddrFamily:      &networkservice.IpFamily{Family: networkservice.IpFamily_IPV6},
+			RequiredNumber:  1,
+			RequestedNumber: 1,
+			PrefixLen:       120,
+		},
+	}
+
+	conn, err := srv.Request(context.Background(), request)
+	require.NoError(t, err)
+	validateConn(t, conn, "fe80::/128", "fe80::1/128")
+	require.Empty(t, conn.GetContext().GetExtraContext())
+}
+
+func TestDualStackExclude(t *testing.T) {
+	srv := newDualStackIpamServer(t)
+
+	request := newRequest()
+	request.Connection.Context.IpContext.ExcludedPrefixes = []string{"192.168.0.0/31", "fe80::/127", "fe80::2/128"}
+
+	conn, err := srv.Request(context.Background(), request)
+	require.NoError(t, err)
+	validateDualStackConn(t, conn, "192.168.0.2/32", "192.168.0.3/32", "fe80::3/128", "fe80::4/128")
+
+	conn.Context.IpContext.ExcludedPrefixes = []string{"fe80::4/128"}
+
+	conn, err = srv.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	validateDualStackConn(t, conn, "192.168.0.0/32", "192.168.0.1/32", "fe80::/128", "fe80::1/128")
+}
+
+func TestQuarantine(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	clockMock := clockmock.NewMock()
+	ctx := clock.WithClock(context.Background(), clockMock)
+
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(ctx, []*net.IPNet{ipNet}, point2pointipam.WithQuarantine(time.Minute)),
+	)
+
+	conn1, err := srv.Request(ctx, newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn1, "192.168.0.0/32", "192.168.0.1/32")
+
+	_, err = srv.Close(ctx, conn1)
+	require.NoError(t, err)
+
+	conn2, err := srv.Request(ctx, newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+
+	clockMock.Add(time.Minute)
+
+	conn3, err := srv.Request(ctx, newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn3, "192.168.0.0/32", "192.168.0.1/32")
+}
+
+func TestRoundRobinStrategy(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.3.4/16")
+	require.NoError(t, err)
+
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(context.Background(), []*net.IPNet{ipNet}, point2pointipam.WithAllocationStrategy(ippool.RoundRobinStrategy)),
+	)
+
+	conn1, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn1, "192.168.0.0/32", "192.168.0.1/32")
+
+	_, err = srv.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn2, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package point2pointipam
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Allocation - IP addresses allocated for the connection, secondary addresses are allocated only in dual-stack mode
+type Allocation struct {
+	ConnectionID     string    `json:"connectionId"`
+	SrcAddr          string    `json:"srcAddr"`
+	DstAddr          string    `json:"dstAddr"`
+	SecondarySrcAddr string    `json:"secondarySrcAddr,omitempty"`
+	SecondaryDstAddr string    `json:"secondaryDstAddr,omitempty"`
+	Allocated        time.Time `json:"allocated,omitempty"`
+	Expires          time.Time `json:"expires,omitempty"`
+}
+
+// Store - persistent storage for the IPAM allocations
+type Store interface {
+	// Load - returns all stored allocations
+	Load() ([]*Allocation, error)
+	// Store - stores the allocation replacing the previous one for the same connection ID
+	Store(allocation *Allocation) error
+	// Delete - deletes the allocation for the connection ID
+	Delete(connectionID string) error
+}
+
+type fileStore struct {
+	path        string
+	allocations map[string]*Allocation
+	loaded      bool
+	mu          sync.Mutex
+}
+
+// NewFileStore - returns a new Store keeping the snapshot of all allocations in the JSON file at the path. File is
+// atomically rewritten on each change, IPAM server stores allocations only on creation, so refreshes don't touch it.
+func NewFileStore(path string) Store {
+	return &fileStore{
+		path:        path,
+		allocations: make(map[string]*Allocation),
+	}
+}
+
+func (s *fileStore) Load() ([]*Allocation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	rv := make([]*Allocation, 0, len(s.allocations))
+	for _, allocation := range s.allocations {
+		allocationCopy := *allocation
+		rv = append(rv, &allocationCopy)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].ConnectionID < rv[j].ConnectionID
+	})
+	return rv, nil
+}
+
+func (s *fileStore) Store(allocation *Allocation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	allocationCopy := *allocation
+	s.allocations[allocation.ConnectionID] = &allocationCopy
+
+	return s.save()
+}
+
+func (s *fileStore) Delete(connectionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	if _, ok := s.allocations[connectionID]; !ok {
+		return nil
+	}
+	delete(s.allocations, connectionID)
+
+	return s.save()
+}
+
+func (s *fileStore) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	bytes, err := ioutil.ReadFile(s.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return errors.Wrapf(err, "failed to read IPAM state from %s", s.path)
+	default:
+		var allocations []*Allocation
+		if err = json.Unmarshal(bytes, &allocations); err != nil {
+			return errors.Wrapf(err, "failed to parse IPAM state from %s", s.path)
+		}
+		for _, allocation := range allocations {
+			s.allocations[allocation.ConnectionID] = allocation
+		}
+	}
+
+	s.loaded = true
+	return nil
+}
+
+func (s *fileStore) save() error {
+	allocations := make([]*Allocation, 0, len(s.allocations))
+	for _, allocation := range s.allocations {
+		allocations = append(allocations, allocation)
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].ConnectionID < allocations[j].ConnectionID
+	})
+
+	bytes, err := json.Marshal(allocations)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal IPAM state")
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for IPAM state %s", s.path)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err = tmpFile.Write(bytes); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write IPAM state to %s", tmpFile.Name())
+	}
+	if err = tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write IPAM state to %s", tmpFile.Name())
+	}
+
+	return errors.Wrapf(os.Rename(tmpFile.Name(), s.path), "failed to save IPAM state to %s", s.path)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prefixipam provides an IPAM server chain element delegating whole prefixes requested with
+// IPContext.ExtraPrefixRequest
+package prefixipam
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/prefixpool"
+)
+
+type prefixIPAMServer struct {
+	prefixes []string
+	pool     *prefixpool.PrefixPool
+	once     sync.Once
+	initErr  error
+}
+
+// NewServer - creates a new NetworkServiceServer chain element allocating prefixes requested with
+//             IPContext.ExtraPrefixRequest from the given prefixes.
+//             Allocated prefixes are set to IPContext.ExtraPrefixes, destination routes to them are added.
+func NewServer(prefixes ...string) networkservice.NetworkServiceServer {
+	return &prefixIPAMServer{
+		prefixes: prefixes,
+	}
+}
+
+func (s *prefixIPAMServer) init() {
+	s.pool, s.initErr = prefixpool.New(s.prefixes...)
+}
+
+func (s *prefixIPAMServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.once.Do(s.init)
+	if s.initErr != nil {
+		return nil, s.initErr
+	}
+
+	conn := request.GetConnection()
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetIpContext() == nil {
+		conn.GetContext().IpContext = &networkservice.IPContext{}
+	}
+	ipContext := conn.GetContext().GetIpContext()
+
+	prefixes, loaded := loadPrefixes(ctx)
+	if len(ipContext.GetExtraPrefixRequest()) == 0 && !loaded {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	var err error
+	if loaded && intersects(prefixes, ipContext.GetExcludedPrefixes()) {
+		// some of the existing prefixes are excluded
+		for _, prefix := range prefixes {
+			deleteString(&ipContext.ExtraPrefixes, prefix)
+			deleteRoute(&ipContext.DstRoutes, prefix)
+		}
+		s.release(ctx, conn.GetId())
+		loaded = false
+	}
+	if !loaded {
+		if prefixes, err = s.pool.ExtractExtraPrefixes(conn.GetId(), ipContext.GetExcludedPrefixes(), ipContext.GetExtraPrefixRequest()...); err != nil {
+			return nil, err
+		}
+		storePrefixes(ctx, prefixes)
+	}
+
+	for _, prefix := range prefixes {
+		addString(&ipContext.ExtraPrefixes, prefix)
+		addRoute(&ipContext.DstRoutes, prefix)
+	}
+
+	conn, err = next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !loaded {
+			s.release(ctx, request.GetConnection().GetId())
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *prefixIPAMServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.once.Do(s.init)
+	if s.initErr != nil {
+		return nil, s.initErr
+	}
+
+	if _, ok := loadPrefixes(ctx); ok {
+		s.release(ctx, conn.GetId())
+	}
+
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *prefixIPAMServer) release(ctx context.Context, connID string) {
+	if err := s.pool.Release(connID); err != nil {
+		log.FromContext(ctx).WithField("prefixIPAMServer", "release").Errorf("Failed to release prefixes: %s", err.Error())
+	}
+}
+
+func intersects(prefixes, excludedPrefixes []string) bool {
+	for _, excludedPrefix := range excludedPrefixes {
+		_, excludedNet, err := net.ParseCIDR(excludedPrefix)
+		if err != nil {
+			continue
+		}
+		for _, prefix := range prefixes {
+			_, ipNet, err := net.ParseCIDR(prefix)
+			if err != nil {
+				continue
+			}
+			if ipNet.Contains(excludedNet.IP) || excludedNet.Contains(ipNet.IP) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func deleteString(values *[]string, value string) {
+	for i, v := range *values {
+		if v == value {
+			*values = append((*values)[:i], (*values)[i+1:]...)
+			return
+		}
+	}
+}
+
+func addString(values *[]string, value string) {
+	for _, v := range *values {
+		if v == value {
+			return
+		}
+	}
+	*values = append(*values, value)
+}
+
+func deleteRoute(routes *[]*networkservice.Route, prefix string) {
+	for i, route := range *routes {
+		if route.Prefix == prefix {
+			*routes = append((*routes)[:i], (*routes)[i+1:]...)
+			return
+		}
+	}
+}
+
+func addRoute(routes *[]*networkservice.Route, prefix string) {
+	for _, route := range *routes {
+		if route.Prefix == prefix {
+			return
+		}
+	}
+	*routes = append(*routes, &networkservice.Route{
+		Prefix: prefix,
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefixipam_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/prefixipam"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newRequest(connID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Context: &networkservice.ConnectionContext{
+				IpContext: &networkservice.IPContext{
+					ExtraPrefixRequest: []*networkservice.ExtraPrefixRequest{
+						{
+							AddrFamily:      &networkservice.IpFamily{Family: networkservice.IpFamily_IPV4},
+							RequiredNumber:  1,
+							RequestedNumber: 1,
+							PrefixLen:       28,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateConn(t *testing.T, conn *networkservice.Connection, prefix string) {
+	require.Equal(t, []string{prefix}, conn.GetContext().GetIpContext().GetExtraPrefixes())
+	require.Equal(t, []*networkservice.Route{
+		{
+			Prefix: prefix,
+		},
+	}, conn.GetContext().GetIpContext().GetDstRoutes())
+}
+
+func TestServer(t *testing.T) {
+	srv := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		prefixipam.NewServer("10.0.0.0/24"),
+	)
+
+	conn1, err := srv.Request(context.Background(), newRequest("1"))
+	require.NoError(t, err)
+	validateConn(t, conn1, "10.0.0.0/28")
+
+	conn2, err := srv.Request(context.Background(), newRequest("2"))
+	require.NoError(t, err)
+	validateConn(t, conn2, "10.0.0.16/28")
+
+	_, err = srv.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn3, err := srv.Request(context.Background(), newRequest("3"))
+	require.NoError(t, err)
+	validateConn(t, conn3, "10.0.0.0/28")
+}
+
+func TestServer_Refresh(t *testing.T) {
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		prefixipam.NewServer("10.0.0.0/24"),
+	)
+
+	conn, err := srv.Request(context.Background(), newRequest(""))
+	require.NoError(t, err)
+	validateConn(t, conn, "10.0.0.0/28")
+
+	conn, err = srv.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	validateConn(t, conn, "10.0.0.0/28")
+}
+
+func TestServer_ExcludedPrefixes(t *testing.T) {
+	srv := next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		prefixipam.NewServer("10.0.0.0/24"),
+	)
+
+	request := newRequest("")
+	request.Connection.Context.IpContext.ExcludedPrefixes = []string{"10.0.0.0/27"}
+
+	conn, err := srv.Request(context.Background(), request)
+	require.NoError(t, err)
+	validateConn(t, conn, "10.0.0.32/28")
+
+	conn.Context.IpContext.ExcludedPrefixes = []string{"10.0.0.32/28"}
+
+	conn, err = srv.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	validateConn(t, conn, "10.0.0.48/28")
+}
+
+func TestServer_NoExtraPrefixRequest(t *testing.T) {
+	srv := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		prefixipam.NewServer("10.0.0.0/24"),
+	)
+
+	conn, err := srv.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "1"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, conn.GetContext().GetIpContext().GetExtraPrefixes())
+	require.Empty(t, conn.GetContext().GetIpContext().GetDstRoutes())
+}
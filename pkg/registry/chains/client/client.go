@@ -57,6 +57,16 @@ func NewNetworkServiceRegistryClient(cc grpc.ClientConnInterface, additionalFunc
 	)
 }
 
+// NewNetworkServiceRegistryRefreshClient creates a new registry.NetworkServiceRegistryClient that can be used for registry.NetworkService registration.
+// Registered network services are periodically re-registered until ctx is done, so they are restored after the registry restart.
+func NewNetworkServiceRegistryRefreshClient(ctx context.Context, cc grpc.ClientConnInterface, additionalFunctionality ...registry.NetworkServiceRegistryClient) registry.NetworkServiceRegistryClient {
+	return NewNetworkServiceRegistryClient(cc,
+		append([]registry.NetworkServiceRegistryClient{
+			refresh.NewNetworkServiceRegistryClient(refresh.WithChainContext(ctx)),
+		}, additionalFunctionality...)...,
+	)
+}
+
 // NewNetworkServiceEndpointRegistryInterposeClient creates a new registry.NetworkServiceEndpointRegistryClient that can be used for cross-nse registration
 func NewNetworkServiceEndpointRegistryInterposeClient(ctx context.Context, cc grpc.ClientConnInterface, additionalFunctionality ...registry.NetworkServiceEndpointRegistryClient) registry.NetworkServiceEndpointRegistryClient {
 	return chain.NewNetworkServiceEndpointRegistryClient(
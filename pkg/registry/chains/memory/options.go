@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"net/url"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
+)
+
+type serverOptions struct {
+	authorizeNSRegistryServer  registry.NetworkServiceRegistryServer
+	authorizeNSERegistryServer registry.NetworkServiceEndpointRegistryServer
+	dialOptions                []grpc.DialOption
+	storage                    memory.Storage
+	peerURLs                   []*url.URL
+	replicateOptions           []replicate.Option
+}
+
+// Option modifies server option value
+type Option func(o *serverOptions)
+
+// WithDialOptions sets gRPC Dial Options for the server
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *serverOptions) {
+		o.dialOptions = dialOptions
+	}
+}
+
+// WithAuthorizeNSRegistryServer sets authorization NetworkServiceRegistry chain element
+func WithAuthorizeNSRegistryServer(authorizeNSRegistryServer registry.NetworkServiceRegistryServer) Option {
+	if authorizeNSRegistryServer == nil {
+		panic("authorizeNSRegistryServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSRegistryServer = authorizeNSRegistryServer
+	}
+}
+
+// WithAuthorizeNSERegistryServer sets authorization NetworkServiceEndpointRegistry chain element
+func WithAuthorizeNSERegistryServer(authorizeNSERegistryServer registry.NetworkServiceEndpointRegistryServer) Option {
+	if authorizeNSERegistryServer == nil {
+		panic("authorizeNSERegistryServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSERegistryServer = authorizeNSERegistryServer
+	}
+}
+
+// WithStorage sets persistent storage for the registry entries. Stored entries are restored on the server creation,
+// expiration timers are rearmed from the stored ExpirationTime.
+func WithStorage(storage memory.Storage) Option {
+	return func(o *serverOptions) {
+		o.storage = storage
+	}
+}
+
+// WithPeers sets URLs of the peer registry replicas. Register/Unregister are replicated to the peers, so clients can
+// use any replica. Replicated requests are applied only if the peer is trusted: see WithPeerIDs and WithPeerAuthFunc.
+func WithPeers(peerURLs ...*url.URL) Option {
+	return func(o *serverOptions) {
+		o.peerURLs = peerURLs
+	}
+}
+
+// WithPeerIDs sets SPIFFE IDs of the peer registry replicas, only requests from these peers are applied as
+// replicated. By default no peers are trusted.
+func WithPeerIDs(peerIDs ...string) Option {
+	return func(o *serverOptions) {
+		o.replicateOptions = append(o.replicateOptions, replicate.WithPeerIDs(peerIDs...))
+	}
+}
+
+// WithPeerAuthFunc sets custom authentication of the peer registry replicas instead of the SPIFFE IDs
+func WithPeerAuthFunc(isPeer replicate.PeerAuthFunc) Option {
+	return func(o *serverOptions) {
+		o.replicateOptions = append(o.replicateOptions, replicate.WithPeerAuthFunc(isPeer))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// restore - registers stored entries with the servers, network service endpoints go first so network services
+// expiration could see them. Expired network service endpoints are deleted from the storage.
+func restore(
+	ctx context.Context,
+	storage memory.Storage,
+	nseServer registry.NetworkServiceEndpointRegistryServer,
+	nsServer registry.NetworkServiceRegistryServer,
+) {
+	logger := log.FromContext(ctx).WithField("memory", "restore")
+
+	nses, err := storage.LoadNetworkServiceEndpoints()
+	if err != nil {
+		logger.Errorf("failed to load network service endpoints: %s", err.Error())
+	}
+	now := clock.FromContext(ctx).Now()
+	for _, nse := range nses {
+		if nse.GetExpirationTime() != nil && nse.GetExpirationTime().AsTime().Before(now) {
+			if err := storage.DeleteNetworkServiceEndpoint(nse.Name); err != nil {
+				logger.Errorf("failed to delete expired network service endpoint %s: %s", nse.Name, err.Error())
+			}
+			continue
+		}
+		if _, err := nseServer.Register(ctx, nse); err != nil {
+			logger.Errorf("failed to restore network service endpoint %s: %s", nse.Name, err.Error())
+		}
+	}
+
+	nss, err := storage.LoadNetworkServices()
+	if err != nil {
+		logger.Errorf("failed to load network services: %s", err.Error())
+	}
+	for _, ns := range nss {
+		if _, err := nsServer.Register(ctx, ns); err != nil {
+			logger.Errorf("failed to restore network service %s: %s", ns.Name, err.Error())
+		}
+	}
+}
@@ -26,41 +26,122 @@ import (
 	"google.golang.org/grpc"
 
 	registryserver "github.com/networkservicemesh/sdk/pkg/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/connect"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/metrics"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/null"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/proxy"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/serialize"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
 )
 
+const registryName = "registry"
+
 // NewServer creates new registry server based on memory storage
 func NewServer(ctx context.Context, expiryDuration time.Duration, proxyRegistryURL *url.URL, options ...grpc.DialOption) registryserver.Registry {
+	return NewServerWithOptions(ctx, expiryDuration, proxyRegistryURL, WithDialOptions(options...))
+}
+
+// NewServerWithOptions creates new registry server based on memory storage configured with the options. Registry
+// calls are not authorized by default, use WithAuthorizeNSRegistryServer and WithAuthorizeNSERegistryServer with
+// authorize.NewNetworkServiceRegistryServer() and authorize.NewNetworkServiceEndpointRegistryServer() to enable the
+// default authorization policies: the peer token should be valid and not expired, and only the owner of the entry (the
+// peer registered it) can refresh or unregister it. Requests replicated by the trusted peer replicas are not authorized
+// since they have been already authorized by the peer, so the entry can be refreshed or unregistered through any replica.
+func NewServerWithOptions(ctx context.Context, expiryDuration time.Duration, proxyRegistryURL *url.URL, options ...Option) registryserver.Registry {
+	opts := &serverOptions{
+		authorizeNSRegistryServer:  authorize.NewNetworkServiceRegistryServer(authorize.Any()),
+		authorizeNSERegistryServer: authorize.NewNetworkServiceEndpointRegistryServer(authorize.Any()),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	memoryOptions := []memory.Option{}
+	if opts.storage != nil {
+		memoryOptions = append(memoryOptions, memory.WithStorage(opts.storage))
+	}
+
+	nseSerializeServer := serialize.NewNetworkServiceEndpointRegistryServer()
+	nseExpireServer := expire.NewNetworkServiceEndpointRegistryServer(ctx, expiryDuration)
+	nseMetricsServer := metrics.NewNetworkServiceEndpointRegistryServer(registryName)
+	nseMemoryServer := memory.NewNetworkServiceEndpointRegistryServer(memoryOptions...)
+
+	nseReplicateServer := null.NewNetworkServiceEndpointRegistryServer()
+	if len(opts.peerURLs) > 0 {
+		nseReplicateServer = replicate.NewNetworkServiceEndpointRegistryServer(ctx,
+			chain.NewNetworkServiceEndpointRegistryServer(nseExpireServer, nseMetricsServer, nseMemoryServer),
+			append([]replicate.Option{
+				replicate.WithPeers(opts.peerURLs...),
+				replicate.WithDialOptions(opts.dialOptions...),
+				replicate.WithExpiryDuration(expiryDuration),
+			}, opts.replicateOptions...)...,
+		)
+	}
+
 	nseChain := chain.NewNetworkServiceEndpointRegistryServer(
-		serialize.NewNetworkServiceEndpointRegistryServer(),
-		expire.NewNetworkServiceEndpointRegistryServer(ctx, expiryDuration),
-		memory.NewNetworkServiceEndpointRegistryServer(),
+		nseSerializeServer,
+		// `replicate` should be before the `authorize` to apply the requests replicated by the trusted peers without
+		// the authorization and before the `expire` to replicate endpoints with the final expiration time.
+		nseReplicateServer,
+		opts.authorizeNSERegistryServer,
+		nseExpireServer,
+		// `metrics` should be after the `expire` to count expired endpoints as unregistered.
+		nseMetricsServer,
+		nseMemoryServer,
 		setid.NewNetworkServiceEndpointRegistryServer(),
 		proxy.NewNetworkServiceEndpointRegistryServer(proxyRegistryURL),
 		connect.NewNetworkServiceEndpointRegistryServer(ctx, func(ctx context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceEndpointRegistryClient {
 			return chain.NewNetworkServiceEndpointRegistryClient(
 				registry.NewNetworkServiceEndpointRegistryClient(cc),
 			)
-		}, connect.WithClientDialOptions(options...)),
+		}, connect.WithClientDialOptions(opts.dialOptions...)),
 	)
+
+	nsSerializeServer := serialize.NewNetworkServiceRegistryServer()
+	nsExpireServer := expire.NewNetworkServiceServer(ctx, adapters.NetworkServiceEndpointServerToClient(nseChain))
+	nsMetricsServer := metrics.NewNetworkServiceRegistryServer(registryName)
+	nsMemoryServer := memory.NewNetworkServiceRegistryServer(memoryOptions...)
+
+	nsReplicateServer := null.NewNetworkServiceRegistryServer()
+	if len(opts.peerURLs) > 0 {
+		nsReplicateServer = replicate.NewNetworkServiceRegistryServer(ctx,
+			chain.NewNetworkServiceRegistryServer(nsExpireServer, nsMetricsServer, nsMemoryServer),
+			append([]replicate.Option{
+				replicate.WithPeers(opts.peerURLs...),
+				replicate.WithDialOptions(opts.dialOptions...),
+			}, opts.replicateOptions...)...,
+		)
+	}
+
 	nsChain := chain.NewNetworkServiceRegistryServer(
-		serialize.NewNetworkServiceRegistryServer(),
-		expire.NewNetworkServiceServer(ctx, adapters.NetworkServiceEndpointServerToClient(nseChain)),
-		memory.NewNetworkServiceRegistryServer(),
+		nsSerializeServer,
+		nsReplicateServer,
+		opts.authorizeNSRegistryServer,
+		nsExpireServer,
+		nsMetricsServer,
+		nsMemoryServer,
 		proxy.NewNetworkServiceRegistryServer(proxyRegistryURL),
 		connect.NewNetworkServiceRegistryServer(ctx, func(ctx context.Context, cc grpc.ClientConnInterface) registry.NetworkServiceRegistryClient {
 			return chain.NewNetworkServiceRegistryClient(
 				registry.NewNetworkServiceRegistryClient(cc),
 			)
-		}, connect.WithClientDialOptions(options...)),
+		}, connect.WithClientDialOptions(opts.dialOptions...)),
 	)
 
+	if opts.storage != nil {
+		// Replay stored entries through the local part of the chains to rearm `expire` timers, count them in metrics and
+		// replicate them to the peers
+		restore(ctx, opts.storage,
+			chain.NewNetworkServiceEndpointRegistryServer(nseSerializeServer, nseReplicateServer, nseExpireServer, nseMetricsServer, nseMemoryServer),
+			chain.NewNetworkServiceRegistryServer(nsSerializeServer, nsReplicateServer, nsExpireServer, nsMetricsServer, nsMemoryServer),
+		)
+	}
+
 	return registryserver.NewServer(nsChain, nseChain)
 }
@@ -0,0 +1,304 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	registryserver "github.com/networkservicemesh/sdk/pkg/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/chains/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	registrymemory "github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+func findNSEs(ctx context.Context, t *testing.T, server registry.NetworkServiceEndpointRegistryServer) (names []string) {
+	ch := make(chan *registry.NetworkServiceEndpoint, 10)
+	err := server.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+	require.NoError(t, err)
+	close(ch)
+
+	for nse := range ch {
+		names = append(names, nse.Name)
+	}
+	return names
+}
+
+func TestNewServer_Restore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	storage, err := registrymemory.NewFileStorage(dir)
+	require.NoError(t, err)
+	defer func() { _ = storage.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reg := memory.NewServerWithOptions(ctx, time.Hour, nil, memory.WithStorage(storage))
+
+	_, err = reg.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(500 * time.Millisecond)),
+	})
+	require.NoError(t, err)
+	_, err = reg.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-2",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	// Expired endpoint is dropped on restore
+	require.NoError(t, storage.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+		Name:                "nse-expired",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(-time.Hour)),
+	}))
+
+	// Restart the registry
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	reg = memory.NewServerWithOptions(ctx, time.Hour, nil, memory.WithStorage(storage))
+
+	require.ElementsMatch(t, []string{"nse-1", "nse-2"}, findNSEs(ctx, t, reg.NetworkServiceEndpointRegistryServer()))
+
+	// Expiration timer is rearmed from the stored ExpirationTime
+	require.Eventually(t, func() bool {
+		return len(findNSEs(ctx, t, reg.NetworkServiceEndpointRegistryServer())) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"nse-2"}, findNSEs(ctx, t, reg.NetworkServiceEndpointRegistryServer()))
+
+	nses, err := storage.LoadNetworkServiceEndpoints()
+	require.NoError(t, err)
+	require.Len(t, nses, 1)
+	require.Equal(t, "nse-2", nses[0].Name)
+}
+
+func TestNewServer_RestoreExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	storage, err := registrymemory.NewFileStorage(dir)
+	require.NoError(t, err)
+	defer func() { _ = storage.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reg := memory.NewServerWithOptions(ctx, 200*time.Millisecond, nil, memory.WithStorage(storage))
+
+	_, err = reg.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	require.NoError(t, err)
+	_, err = reg.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-2",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	// Stored expiration time is clamped by the registry expiry duration
+	nses, err := storage.LoadNetworkServiceEndpoints()
+	require.NoError(t, err)
+	require.Len(t, nses, 2)
+	for _, nse := range nses {
+		require.NotNil(t, nse.ExpirationTime)
+		require.False(t, nse.ExpirationTime.AsTime().After(time.Now().Add(200*time.Millisecond)))
+	}
+
+	// Restart the registry after the endpoints have expired
+	cancel()
+	time.Sleep(300 * time.Millisecond)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	reg = memory.NewServerWithOptions(ctx, 200*time.Millisecond, nil, memory.WithStorage(storage))
+
+	require.Empty(t, findNSEs(ctx, t, reg.NetworkServiceEndpointRegistryServer()))
+
+	nses, err = storage.LoadNetworkServiceEndpoints()
+	require.NoError(t, err)
+	require.Empty(t, nses)
+}
+
+// newCertificate - returns self-signed TLS certificate with the SPIFFE ID
+func newCertificate(t *testing.T, spiffeID string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        cert,
+	}
+}
+
+// withPeer - returns context with the TLS peer having the certificate and the token signed by the certificate key
+func withPeer(ctx context.Context, t *testing.T, cert tls.Certificate) context.Context {
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodES256, &jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}).SignedString(cert.PrivateKey)
+	require.NoError(t, err)
+
+	return metadata.NewIncomingContext(peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert.Leaf},
+			},
+		},
+	}), metadata.Pairs(
+		"nsm-client-token", tok,
+		"nsm-client-token-expires", time.Now().Add(time.Hour).Format(time.RFC3339Nano),
+	))
+}
+
+func reserveURL(t *testing.T) *url.URL {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	u := grpcutils.AddressToURL(listener.Addr())
+	require.NoError(t, listener.Close())
+	return u
+}
+
+// startReplica - starts the registry replica with the default authorization, replicas are connected with mTLS and
+// trust each other SPIFFE IDs
+func startReplica(ctx context.Context, t *testing.T, cert tls.Certificate, serveURL, peerURL *url.URL, peerIDs ...string) registryserver.Registry {
+	// #nosec
+	clientCreds := credentials.NewTLS(&tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: true,
+	})
+	serverCreds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+
+	reg := memory.NewServerWithOptions(ctx, time.Minute, nil,
+		memory.WithDialOptions(grpc.WithTransportCredentials(clientCreds)),
+		memory.WithAuthorizeNSRegistryServer(authorize.NewNetworkServiceRegistryServer()),
+		memory.WithAuthorizeNSERegistryServer(authorize.NewNetworkServiceEndpointRegistryServer()),
+		memory.WithPeers(peerURL),
+		memory.WithPeerIDs(peerIDs...),
+	)
+
+	grpcServer := grpc.NewServer(grpc.Creds(serverCreds))
+	reg.Register(grpcServer)
+
+	select {
+	case err := <-grpcutils.ListenAndServe(ctx, serveURL, grpcServer):
+		require.NoError(t, err)
+	default:
+	}
+
+	return reg
+}
+
+func TestNewServer_ReplicasAuthorization(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const (
+		firstID  = "spiffe://test.com/registry-1"
+		secondID = "spiffe://test.com/registry-2"
+	)
+
+	firstURL, secondURL := reserveURL(t), reserveURL(t)
+	first := startReplica(ctx, t, newCertificate(t, firstID), firstURL, secondURL, firstID, secondID).
+		NetworkServiceEndpointRegistryServer()
+	second := startReplica(ctx, t, newCertificate(t, secondID), secondURL, firstURL, firstID, secondID).
+		NetworkServiceEndpointRegistryServer()
+
+	ownerCtx := withPeer(ctx, t, newCertificate(t, "spiffe://test.com/owner"))
+	otherCtx := withPeer(ctx, t, newCertificate(t, "spiffe://test.com/other"))
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(time.Minute)),
+	}
+
+	_, err := first.Register(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(findNSEs(ownerCtx, t, second)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The owner can refresh the endpoint through the other replica
+	_, err = second.Register(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	// The other peer still cannot unregister the endpoint
+	_, err = first.Unregister(otherCtx, nse.Clone())
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = second.Unregister(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(findNSEs(ownerCtx, t, first)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type authorizeNSServer struct {
+	policies policiesList
+	owners   owners
+}
+
+// NewNetworkServiceRegistryServer - returns a new authorization registry.NetworkServiceRegistryServer checking the
+// network service (or the query for Find) and the peer identity against the policies, default policies are used if
+// no options are passed
+func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegistryServer {
+	s := &authorizeNSServer{
+		policies: defaultPolicies(),
+	}
+	for _, o := range opts {
+		o.apply(&s.policies)
+	}
+	return s
+}
+
+func (s *authorizeNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.policies.check(ctx, s.input(ctx, ns)); err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.owners.store(resp.GetName(), peerID(ctx), time.Time{})
+
+	return resp, nil
+}
+
+func (s *authorizeNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if err := s.policies.check(server.Context(), query); err != nil {
+		return err
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *authorizeNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if err := s.policies.check(ctx, s.input(ctx, ns)); err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.owners.delete(ns.GetName())
+
+	return resp, nil
+}
+
+func (s *authorizeNSServer) input(ctx context.Context, ns *registry.NetworkService) *nsInput {
+	return &nsInput{
+		NetworkService: ns,
+		Owner:          s.owners.load(ctx, ns.GetName()),
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type authorizeNSEServer struct {
+	policies policiesList
+	owners   owners
+}
+
+// NewNetworkServiceEndpointRegistryServer - returns a new authorization registry.NetworkServiceEndpointRegistryServer
+// checking the network service endpoint (or the query for Find) and the peer identity against the policies, default
+// policies are used if no options are passed
+func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	s := &authorizeNSEServer{
+		policies: defaultPolicies(),
+	}
+	for _, o := range opts {
+		o.apply(&s.policies)
+	}
+	return s
+}
+
+func (s *authorizeNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.policies.check(ctx, s.input(ctx, nse)); err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	var expires time.Time
+	if resp.GetExpirationTime() != nil {
+		expires = resp.GetExpirationTime().AsTime()
+	}
+	s.owners.store(resp.GetName(), peerID(ctx), expires)
+
+	return resp, nil
+}
+
+func (s *authorizeNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if err := s.policies.check(server.Context(), query); err != nil {
+		return err
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *authorizeNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if err := s.policies.check(ctx, s.input(ctx, nse)); err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	s.owners.delete(nse.GetName())
+
+	return resp, nil
+}
+
+func (s *authorizeNSEServer) input(ctx context.Context, nse *registry.NetworkServiceEndpoint) *nseInput {
+	return &nseInput{
+		NetworkServiceEndpoint: nse,
+		Owner:                  s.owners.load(ctx, nse.GetName()),
+	}
+}
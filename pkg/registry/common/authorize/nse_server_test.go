@@ -0,0 +1,276 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+func testPolicy() authorize.Policy {
+	return opa.WithPolicyFromSource(`
+		package test
+
+		default allow = false
+
+		allow {
+			input.name = "allowed"
+		}
+
+		allow {
+			input.network_service_endpoint.name = "allowed"
+		}
+`, "allow", opa.True)
+}
+
+func TestAuthorizeNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	suits := []struct {
+		name   string
+		nse    *registry.NetworkServiceEndpoint
+		denied bool
+	}{
+		{
+			name:   "simple positive test",
+			nse:    &registry.NetworkServiceEndpoint{Name: "allowed"},
+			denied: false,
+		},
+		{
+			name:   "simple negative test",
+			nse:    &registry.NetworkServiceEndpoint{Name: "not_allowed"},
+			denied: true,
+		},
+	}
+
+	for i := range suits {
+		s := suits[i]
+		t.Run(s.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mem := memory.NewNetworkServiceEndpointRegistryServer()
+			server := next.NewNetworkServiceEndpointRegistryServer(
+				authorize.NewNetworkServiceEndpointRegistryServer(authorize.WithPolicies(testPolicy())),
+				mem,
+			)
+
+			checkResult := func(err error) {
+				if !s.denied {
+					require.NoError(t, err, "request expected to be not denied")
+					return
+				}
+				require.Error(t, err, "request expected to be denied")
+				st, ok := status.FromError(err)
+				require.True(t, ok, "error without error status code: "+err.Error())
+				require.Equal(t, codes.PermissionDenied, st.Code(), "wrong error status code")
+			}
+
+			_, err := server.Register(ctx, s.nse)
+			checkResult(err)
+
+			ch := make(chan *registry.NetworkServiceEndpoint, 1)
+			err = server.Find(&registry.NetworkServiceEndpointQuery{
+				NetworkServiceEndpoint: s.nse,
+			}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+			checkResult(err)
+
+			_, err = server.Unregister(ctx, s.nse)
+			checkResult(err)
+		})
+	}
+}
+
+func TestAuthorizeNSEServer_DefaultPolicies(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		authorize.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	// No token is passed with the per RPC credentials
+	_, err := server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, st.Code())
+
+	server = next.NewNetworkServiceEndpointRegistryServer(
+		authorize.NewNetworkServiceEndpointRegistryServer(authorize.Any()),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err = server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+}
+
+// withPeer - returns context with the TLS peer having the spiffeID certificate and the token with the subject signed
+// by the certificate key
+func withPeer(ctx context.Context, t *testing.T, spiffeID, subject string) context.Context {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodES256, &jwt.StandardClaims{
+		Subject:   subject,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}).SignedString(key)
+	require.NoError(t, err)
+
+	return withToken(peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			},
+		},
+	}), tok)
+}
+
+func withToken(ctx context.Context, tok string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(
+		"nsm-client-token", tok,
+		"nsm-client-token-expires", time.Now().Add(time.Hour).Format(time.RFC3339Nano),
+	))
+}
+
+func requireDenied(t *testing.T, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, st.Code())
+}
+
+func TestAuthorizeNSEServer_Owner(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	clockMock := clockmock.NewMock()
+	ctx := clock.WithClock(context.Background(), clockMock)
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		authorize.NewNetworkServiceEndpointRegistryServer(authorize.WithPolicies(opa.WithRegistryOwnerPolicy())),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	ownerCtx := withPeer(ctx, t, "spiffe://test.com/owner", "spiffe://test.com/owner")
+	otherCtx := withPeer(ctx, t, "spiffe://test.com/other", "spiffe://test.com/other")
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:           "nse",
+		ExpirationTime: timestamppb.New(clockMock.Now().Add(time.Minute)),
+	}
+
+	_, err := server.Register(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	// Only the owner can refresh or unregister the endpoint
+	_, err = server.Register(otherCtx, nse.Clone())
+	requireDenied(t, err)
+
+	_, err = server.Unregister(otherCtx, nse.Clone())
+	requireDenied(t, err)
+
+	_, err = server.Register(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	_, err = server.Unregister(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	// Unregistered endpoint can be registered by anyone
+	_, err = server.Register(otherCtx, nse.Clone())
+	require.NoError(t, err)
+
+	// Expired endpoint can be registered by anyone
+	clockMock.Add(2 * time.Minute)
+
+	_, err = server.Register(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+}
+
+func TestAuthorizeNSEServer_ForgedSubject(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		authorize.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	ownerCtx := withPeer(context.Background(), t, "spiffe://test.com/owner", "spiffe://test.com/owner")
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse"}
+
+	_, err := server.Register(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+
+	// Token signed by the peer key with the forged subject
+	forgedCtx := withPeer(context.Background(), t, "spiffe://test.com/other", "spiffe://test.com/owner")
+
+	_, err = server.Unregister(forgedCtx, nse.Clone())
+	requireDenied(t, err)
+
+	// Unsigned token with the forged subject
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
+		Subject: "spiffe://test.com/owner",
+	}).SignedString([]byte("super secret"))
+	require.NoError(t, err)
+
+	_, err = server.Unregister(withToken(forgedCtx, tok), nse.Clone())
+	requireDenied(t, err)
+
+	_, err = server.Unregister(ownerCtx, nse.Clone())
+	require.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+// Option is authorization option for registry server
+type Option interface {
+	apply(*policiesList)
+}
+
+// Any authorizes any registry call
+func Any() Option {
+	return WithPolicies(nil)
+}
+
+// WithPolicies sets custom policies
+func WithPolicies(p ...Policy) Option {
+	return optionFunc(func(l *policiesList) {
+		*l = p
+	})
+}
+
+type optionFunc func(*policiesList)
+
+func (f optionFunc) apply(a *policiesList) {
+	f(a)
+}
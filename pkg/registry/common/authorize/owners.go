@@ -0,0 +1,121 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+// nsInput - policy input for the network service with its owner: network service fields are kept in the root of the
+// input for compatibility with the policies expecting *registry.NetworkService as input
+type nsInput struct {
+	*registry.NetworkService
+	Owner string `json:"owner,omitempty"`
+}
+
+// nseInput - policy input for the network service endpoint with its owner: network service endpoint fields are kept in
+// the root of the input for compatibility with the policies expecting *registry.NetworkServiceEndpoint as input
+type nseInput struct {
+	*registry.NetworkServiceEndpoint
+	Owner string `json:"owner,omitempty"`
+}
+
+type owner struct {
+	id      string
+	expires time.Time
+}
+
+// owners - identities of the peers registered the entries, the owner is forgotten on Unregister or when the entry
+// expires
+type owners struct {
+	owners map[string]*owner
+	mu     sync.Mutex
+}
+
+func (o *owners) load(ctx context.Context, name string) string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entryOwner, ok := o.owners[name]
+	if !ok {
+		return ""
+	}
+	if !entryOwner.expires.IsZero() && clock.FromContext(ctx).Now().After(entryOwner.expires) {
+		delete(o.owners, name)
+		return ""
+	}
+	return entryOwner.id
+}
+
+func (o *owners) store(name, id string, expires time.Time) {
+	if id == "" {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.owners == nil {
+		o.owners = make(map[string]*owner)
+	}
+	o.owners[name] = &owner{
+		id:      id,
+		expires: expires,
+	}
+}
+
+func (o *owners) delete(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.owners, name)
+}
+
+// peerID - returns the peer SPIFFE ID authenticated by TLS, the token subject is not used since the peer can sign a
+// token with any subject
+func peerID(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	var tlsInfo credentials.TLSInfo
+	switch authInfo := p.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		tlsInfo = authInfo
+	case *credentials.TLSInfo:
+		tlsInfo = *authInfo
+	default:
+		return ""
+	}
+	if len(tlsInfo.State.PeerCertificates) == 0 {
+		return ""
+	}
+	spiffeID, err := x509svid.IDFromCert(tlsInfo.State.PeerCertificates[0])
+	if err != nil {
+		return ""
+	}
+	return spiffeID.String()
+}
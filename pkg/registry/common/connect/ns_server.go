@@ -24,6 +24,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
@@ -86,12 +87,19 @@ func (c *connectNSServer) connect(ctx context.Context) registry.NetworkServiceRe
 	// Use new context with longer lifetime to use with client
 	ctx = extend.WithValuesFromContext(c.ctx, ctx)
 	client := clienturl.NewNetworkServiceRegistryClient(ctx, c.clientFactory, c.dialOptions...)
-	cached, _ := c.cache.LoadOrStore(key, &nsCacheEntry{
-		expirationTimer: time.AfterFunc(c.connectExpiration, func() {
-			c.cache.Delete(key)
-		}),
+	entry := &nsCacheEntry{
 		client: client,
+	}
+	entry.expirationTimer = time.AfterFunc(c.connectExpiration, func() {
+		c.cache.Delete(key)
+		metrics.FromContext(c.ctx).ConnectClientRemoved(metrics.NetworkServiceKind)
 	})
+	cached, loaded := c.cache.LoadOrStore(key, entry)
+	if loaded {
+		entry.expirationTimer.Stop()
+	} else {
+		metrics.FromContext(c.ctx).ConnectClientAdded(metrics.NetworkServiceKind)
+	}
 	return cached.client
 }
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
@@ -87,12 +88,19 @@ func (c *connectNSEServer) connect(ctx context.Context) registry.NetworkServiceE
 	}
 	ctx = extend.WithValuesFromContext(c.ctx, ctx)
 	client := clienturl.NewNetworkServiceEndpointRegistryClient(ctx, c.clientFactory, c.dialOptions...)
-	cached, _ := c.cache.LoadOrStore(key, &nseCacheEntry{
-		expirationTimer: time.AfterFunc(c.connectExpiration, func() {
-			c.cache.Delete(key)
-		}),
+	entry := &nseCacheEntry{
 		client: client,
+	}
+	entry.expirationTimer = time.AfterFunc(c.connectExpiration, func() {
+		c.cache.Delete(key)
+		metrics.FromContext(c.ctx).ConnectClientRemoved(metrics.EndpointKind)
 	})
+	cached, loaded := c.cache.LoadOrStore(key, entry)
+	if loaded {
+		entry.expirationTimer.Stop()
+	} else {
+		metrics.FromContext(c.ctx).ConnectClientAdded(metrics.EndpointKind)
+	}
 	return cached.client
 }
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
@@ -67,7 +68,7 @@ func (n *expireNSServer) checkUpdates(eventCh <-chan *registry.NetworkServiceEnd
 						ctx := state.Context
 						delete(state.Timers, nse.Name)
 						state.Unlock()
-						_, _ = n.Unregister(ctx, &registry.NetworkService{Name: ns})
+						n.unregisterExpired(ctx, ns)
 					})
 				}
 				state.Unlock()
@@ -79,7 +80,7 @@ func (n *expireNSServer) checkUpdates(eventCh <-chan *registry.NetworkServiceEnd
 				if timer.Stop() {
 					ctx := state.Context
 					state.Unlock()
-					_, _ = n.Unregister(ctx, &registry.NetworkService{Name: ns})
+					n.unregisterExpired(ctx, ns)
 					continue
 				}
 				state.Unlock()
@@ -93,6 +94,12 @@ func (n *expireNSServer) checkUpdates(eventCh <-chan *registry.NetworkServiceEnd
 	}
 }
 
+func (n *expireNSServer) unregisterExpired(ctx context.Context, ns string) {
+	if _, err := n.Unregister(ctx, &registry.NetworkService{Name: ns}); err == nil {
+		metrics.FromContext(n.chainCtx).Expired(metrics.NetworkServiceKind)
+	}
+}
+
 func (n *expireNSServer) Register(ctx context.Context, request *registry.NetworkService) (*registry.NetworkService, error) {
 	n.once.Do(func() {
 		c, err := n.nseClient.Find(n.chainCtx, &registry.NetworkServiceEndpointQuery{
@@ -27,6 +27,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 )
 
 // TODO: rework with serialize (#749)
@@ -68,6 +69,16 @@ func (n *expireNSEServer) Register(ctx context.Context, nse *registry.NetworkSer
 		})
 	}
 
+	// Set the final expiration time to the request, so the next servers see the same value as the response has.
+	requestExpirationTime := time.Now().Add(n.nseExpiration)
+	if nse.ExpirationTime != nil {
+		if nseExpirationTime := nse.ExpirationTime.AsTime().Local(); nseExpirationTime.Before(requestExpirationTime) {
+			requestExpirationTime = nseExpirationTime
+		}
+	}
+	nse = nse.Clone()
+	nse.ExpirationTime = timestamppb.New(requestExpirationTime)
+
 	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
 	if err != nil {
 		if stopped {
@@ -124,7 +135,9 @@ func (n *expireNSEServer) newTimer(
 			unregisterCtx, cancel := context.WithCancel(n.ctx)
 			defer cancel()
 
-			_, _ = next.NetworkServiceEndpointRegistryServer(ctx).Unregister(unregisterCtx, nse)
+			if _, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(unregisterCtx, nse); err == nil {
+				metrics.FromContext(n.ctx).Expired(metrics.EndpointKind)
+			}
 		})
 	})
 
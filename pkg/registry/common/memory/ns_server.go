@@ -27,6 +27,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
@@ -35,6 +36,7 @@ type memoryNSServer struct {
 	executor         serialize.Executor
 	eventChannels    map[string]chan *registry.NetworkService
 	eventChannelSize int
+	storage          Storage
 }
 
 // NewNetworkServiceRegistryServer creates new memory based NetworkServiceRegistryServer
@@ -53,12 +55,22 @@ func (s *memoryNSServer) setEventChannelSize(l int) {
 	s.eventChannelSize = l
 }
 
+func (s *memoryNSServer) setStorage(storage Storage) {
+	s.storage = storage
+}
+
 func (s *memoryNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
 	r, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.storage != nil {
+		if err := s.storage.StoreNetworkService(r); err != nil {
+			return nil, err
+		}
+	}
+
 	s.networkServices.Store(r.Name, r.Clone())
 
 	s.sendEvent(r)
@@ -76,9 +88,14 @@ func (s *memoryNSServer) sendEvent(event *registry.NetworkService) {
 }
 
 func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	matcher, err := matchutils.NewNetworkServiceMatcher(query.NetworkService)
+	if err != nil {
+		return err
+	}
+
 	if !query.Watch {
-		for _, ns := range s.allMatches(query) {
-			if err := server.Send(ns); err != nil {
+		for _, ns := range s.allMatches(query, matcher) {
+			if err = server.Send(ns); err != nil {
 				return err
 			}
 		}
@@ -90,14 +107,13 @@ func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server regist
 
 	s.executor.AsyncExec(func() {
 		s.eventChannels[id] = eventCh
-		for _, entity := range s.allMatches(query) {
+		for _, entity := range s.allMatches(query, matcher) {
 			eventCh <- entity
 		}
 	})
 	defer s.closeEventChannel(id, eventCh)
 
-	var err error
-	for ; err == nil; err = s.receiveEvent(query, server, eventCh) {
+	for ; err == nil; err = s.receiveEvent(matcher, server, eventCh) {
 	}
 	if err != io.EOF {
 		return err
@@ -105,9 +121,19 @@ func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server regist
 	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
 }
 
-func (s *memoryNSServer) allMatches(query *registry.NetworkServiceQuery) (matches []*registry.NetworkService) {
+func (s *memoryNSServer) allMatches(
+	query *registry.NetworkServiceQuery,
+	matcher *matchutils.NetworkServiceMatcher,
+) (matches []*registry.NetworkService) {
+	if name, ok := matchutils.ExactNameOf(query.NetworkService.GetName()); ok {
+		if ns, loaded := s.networkServices.Load(name); loaded && matcher.Match(ns) {
+			matches = append(matches, ns.Clone())
+		}
+		return matches
+	}
+
 	s.networkServices.Range(func(_ string, ns *registry.NetworkService) bool {
-		if matchutils.MatchNetworkServices(query.NetworkService, ns) {
+		if matcher.Match(ns) {
 			matches = append(matches, ns.Clone())
 		}
 		return true
@@ -133,7 +159,7 @@ func (s *memoryNSServer) closeEventChannel(id string, eventCh <-chan *registry.N
 }
 
 func (s *memoryNSServer) receiveEvent(
-	query *registry.NetworkServiceQuery,
+	matcher *matchutils.NetworkServiceMatcher,
 	server registry.NetworkServiceRegistry_FindServer,
 	eventCh <-chan *registry.NetworkService,
 ) error {
@@ -141,7 +167,7 @@ func (s *memoryNSServer) receiveEvent(
 	case <-server.Context().Done():
 		return io.EOF
 	case event := <-eventCh:
-		if matchutils.MatchNetworkServices(query.NetworkService, event) {
+		if matcher.Match(event) {
 			if err := server.Send(event); err != nil {
 				if server.Context().Err() != nil {
 					return io.EOF
@@ -156,5 +182,11 @@ func (s *memoryNSServer) receiveEvent(
 func (s *memoryNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
 	s.networkServices.Delete(ns.Name)
 
+	if s.storage != nil {
+		if err := s.storage.DeleteNetworkService(ns.Name); err != nil {
+			log.FromContext(ctx).Errorf("failed to delete %s from the storage: %s", ns.Name, err.Error())
+		}
+	}
+
 	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
 }
@@ -0,0 +1,243 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+type nameSet map[string]struct{}
+
+// indexKey - secondary index key, it is either a network service name, a network service label key/value or a
+// network service endpoint name
+type indexKey struct {
+	service    string
+	isLabel    bool
+	key, value string
+	name       string
+}
+
+func serviceKey(service string) indexKey {
+	return indexKey{service: service}
+}
+
+func nameKey(name string) indexKey {
+	return indexKey{name: name}
+}
+
+func labelKey(service, key, value string) indexKey {
+	return indexKey{service: service, isLabel: true, key: key, value: value}
+}
+
+// nseIndex - stores network service endpoints by name with the secondary indexes by network service name and by
+// network service label key/value, is safe for concurrent use
+type nseIndex struct {
+	entries map[string]*registry.NetworkServiceEndpoint
+	index   map[indexKey]nameSet
+	mu      sync.RWMutex
+}
+
+func newNSEIndex() *nseIndex {
+	return &nseIndex{
+		entries: make(map[string]*registry.NetworkServiceEndpoint),
+		index:   make(map[indexKey]nameSet),
+	}
+}
+
+func (i *nseIndex) store(nse *registry.NetworkServiceEndpoint) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(nse.Name)
+
+	i.entries[nse.Name] = nse
+	for _, key := range indexKeys(nse) {
+		names, ok := i.index[key]
+		if !ok {
+			names = make(nameSet)
+			i.index[key] = names
+		}
+		names[nse.Name] = struct{}{}
+	}
+}
+
+func (i *nseIndex) delete(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(name)
+}
+
+// find - returns clones of all stored network service endpoints matching the query, matcher is the query compiled
+func (i *nseIndex) find(
+	query *registry.NetworkServiceEndpoint,
+	matcher *matchutils.NetworkServiceEndpointMatcher,
+) (matches []*registry.NetworkServiceEndpoint) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	match := func(nse *registry.NetworkServiceEndpoint) {
+		if nse != nil && matcher.Match(nse) {
+			matches = append(matches, nse.Clone())
+		}
+	}
+
+	if name, ok := matchutils.ExactNameOf(query.GetName()); ok {
+		match(i.entries[name])
+		return matches
+	}
+
+	candidates, ok := i.candidates(query)
+	if !ok {
+		for _, nse := range i.entries {
+			match(nse)
+		}
+		return matches
+	}
+	for name := range candidates {
+		match(i.entries[name])
+	}
+	return matches
+}
+
+// candidates - returns the smallest indexed set of names containing all the query matches, or false if the query has
+// no indexed fields
+func (i *nseIndex) candidates(query *registry.NetworkServiceEndpoint) (rv nameSet, ok bool) {
+	pick := func(set nameSet) {
+		if !ok || len(set) < len(rv) {
+			rv, ok = set, true
+		}
+	}
+
+	for _, service := range query.GetNetworkServiceNames() {
+		pick(i.index[serviceKey(service)])
+	}
+	for service, labels := range query.GetNetworkServiceLabels() {
+		if service == matchutils.AnyNetworkService {
+			continue
+		}
+		for key, value := range labels.GetLabels() {
+			if key == matchutils.SelectorKey {
+				continue
+			}
+			pick(i.index[labelKey(service, key, value)])
+		}
+	}
+	return rv, ok
+}
+
+func (i *nseIndex) remove(name string) {
+	nse, ok := i.entries[name]
+	if !ok {
+		return
+	}
+	delete(i.entries, name)
+	for _, key := range indexKeys(nse) {
+		if names, ok := i.index[key]; ok {
+			delete(names, name)
+			if len(names) == 0 {
+				delete(i.index, key)
+			}
+		}
+	}
+}
+
+func indexKeys(nse *registry.NetworkServiceEndpoint) (keys []indexKey) {
+	for _, service := range nse.GetNetworkServiceNames() {
+		keys = append(keys, serviceKey(service))
+	}
+	for service, labels := range nse.GetNetworkServiceLabels() {
+		for key, value := range labels.GetLabels() {
+			keys = append(keys, labelKey(service, key, value))
+		}
+	}
+	return keys
+}
+
+// nseWatchers - event channels of the watch queries indexed by the query exact name or by the first query network
+// service name, so the event is sent only to the watchers it can match, is not safe for concurrent use
+type nseWatchers struct {
+	watchers map[indexKey]map[string]chan<- *registry.NetworkServiceEndpoint
+	keys     map[string]indexKey
+}
+
+func newNSEWatchers() *nseWatchers {
+	return &nseWatchers{
+		watchers: make(map[indexKey]map[string]chan<- *registry.NetworkServiceEndpoint),
+		keys:     make(map[string]indexKey),
+	}
+}
+
+func (w *nseWatchers) add(id string, query *registry.NetworkServiceEndpoint, ch chan<- *registry.NetworkServiceEndpoint) {
+	key := watchKey(query)
+	if w.watchers[key] == nil {
+		w.watchers[key] = make(map[string]chan<- *registry.NetworkServiceEndpoint)
+	}
+	w.watchers[key][id] = ch
+	w.keys[id] = key
+}
+
+func (w *nseWatchers) delete(id string) {
+	key, ok := w.keys[id]
+	if !ok {
+		return
+	}
+	delete(w.keys, id)
+	delete(w.watchers[key], id)
+	if len(w.watchers[key]) == 0 {
+		delete(w.watchers, key)
+	}
+}
+
+// send - sends clones of the event to all the watchers it can match
+func (w *nseWatchers) send(event *registry.NetworkServiceEndpoint) {
+	keys := []indexKey{{}}
+	if name := event.GetName(); name != "" {
+		keys = append(keys, nameKey(name))
+	}
+	for _, service := range event.GetNetworkServiceNames() {
+		if service != "" {
+			keys = append(keys, serviceKey(service))
+		}
+	}
+
+	sent := make(map[indexKey]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := sent[key]; ok {
+			continue
+		}
+		sent[key] = struct{}{}
+		for _, ch := range w.watchers[key] {
+			ch <- event.Clone()
+		}
+	}
+}
+
+// watchKey - returns the index key all the events matching the query have, or an empty key if there is no such one
+func watchKey(query *registry.NetworkServiceEndpoint) indexKey {
+	if name, ok := matchutils.ExactNameOf(query.GetName()); ok && name != "" {
+		return nameKey(name)
+	}
+	if services := query.GetNetworkServiceNames(); len(services) > 0 && services[0] != "" {
+		return serviceKey(services[0])
+	}
+	return indexKey{}
+}
@@ -28,21 +28,24 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
 type memoryNSEServer struct {
-	networkServiceEndpoints NetworkServiceEndpointSyncMap
+	networkServiceEndpoints *nseIndex
 	executor                serialize.Executor
-	eventChannels           map[string]chan *registry.NetworkServiceEndpoint
+	watchers                *nseWatchers
 	eventChannelSize        int
+	storage                 Storage
 }
 
 // NewNetworkServiceEndpointRegistryServer creates new memory based NetworkServiceEndpointRegistryServer
 func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.NetworkServiceEndpointRegistryServer {
 	s := &memoryNSEServer{
-		eventChannelSize: defaultEventChannelSize,
-		eventChannels:    make(map[string]chan *registry.NetworkServiceEndpoint),
+		networkServiceEndpoints: newNSEIndex(),
+		eventChannelSize:        defaultEventChannelSize,
+		watchers:                newNSEWatchers(),
 	}
 	for _, o := range options {
 		o.apply(s)
@@ -54,13 +57,23 @@ func (s *memoryNSEServer) setEventChannelSize(l int) {
 	s.eventChannelSize = l
 }
 
+func (s *memoryNSEServer) setStorage(storage Storage) {
+	s.storage = storage
+}
+
 func (s *memoryNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	r, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
 	if err != nil {
 		return nil, err
 	}
 
-	s.networkServiceEndpoints.Store(r.Name, r.Clone())
+	if s.storage != nil {
+		if err := s.storage.StoreNetworkServiceEndpoint(r); err != nil {
+			return nil, err
+		}
+	}
+
+	s.networkServiceEndpoints.store(r.Clone())
 
 	s.sendEvent(r)
 
@@ -70,16 +83,19 @@ func (s *memoryNSEServer) Register(ctx context.Context, nse *registry.NetworkSer
 func (s *memoryNSEServer) sendEvent(event *registry.NetworkServiceEndpoint) {
 	event = event.Clone()
 	s.executor.AsyncExec(func() {
-		for _, ch := range s.eventChannels {
-			ch <- event.Clone()
-		}
+		s.watchers.send(event)
 	})
 }
 
 func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	matcher, err := matchutils.NewNetworkServiceEndpointMatcher(query.NetworkServiceEndpoint)
+	if err != nil {
+		return err
+	}
+
 	if !query.Watch {
-		for _, ns := range s.allMatches(query) {
-			if err := server.Send(ns); err != nil {
+		for _, ns := range s.allMatches(query, matcher) {
+			if err = server.Send(ns); err != nil {
 				return err
 			}
 		}
@@ -90,15 +106,14 @@ func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 	id := uuid.New().String()
 
 	s.executor.AsyncExec(func() {
-		s.eventChannels[id] = eventCh
-		for _, entity := range s.allMatches(query) {
+		s.watchers.add(id, query.NetworkServiceEndpoint, eventCh)
+		for _, entity := range s.allMatches(query, matcher) {
 			eventCh <- entity
 		}
 	})
 	defer s.closeEventChannel(id, eventCh)
 
-	var err error
-	for ; err == nil; err = s.receiveEvent(query, server, eventCh) {
+	for ; err == nil; err = s.receiveEvent(matcher, server, eventCh) {
 	}
 	if err != io.EOF {
 		return err
@@ -106,21 +121,18 @@ func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
 }
 
-func (s *memoryNSEServer) allMatches(query *registry.NetworkServiceEndpointQuery) (matches []*registry.NetworkServiceEndpoint) {
-	s.networkServiceEndpoints.Range(func(_ string, nse *registry.NetworkServiceEndpoint) bool {
-		if matchutils.MatchNetworkServiceEndpoints(query.NetworkServiceEndpoint, nse) {
-			matches = append(matches, nse.Clone())
-		}
-		return true
-	})
-	return matches
+func (s *memoryNSEServer) allMatches(
+	query *registry.NetworkServiceEndpointQuery,
+	matcher *matchutils.NetworkServiceEndpointMatcher,
+) []*registry.NetworkServiceEndpoint {
+	return s.networkServiceEndpoints.find(query.NetworkServiceEndpoint, matcher)
 }
 
 func (s *memoryNSEServer) closeEventChannel(id string, eventCh <-chan *registry.NetworkServiceEndpoint) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	s.executor.AsyncExec(func() {
-		delete(s.eventChannels, id)
+		s.watchers.delete(id)
 		cancel()
 	})
 
@@ -134,7 +146,7 @@ func (s *memoryNSEServer) closeEventChannel(id string, eventCh <-chan *registry.
 }
 
 func (s *memoryNSEServer) receiveEvent(
-	query *registry.NetworkServiceEndpointQuery,
+	matcher *matchutils.NetworkServiceEndpointMatcher,
 	server registry.NetworkServiceEndpointRegistry_FindServer,
 	eventCh <-chan *registry.NetworkServiceEndpoint,
 ) error {
@@ -142,7 +154,7 @@ func (s *memoryNSEServer) receiveEvent(
 	case <-server.Context().Done():
 		return io.EOF
 	case event := <-eventCh:
-		if matchutils.MatchNetworkServiceEndpoints(query.NetworkServiceEndpoint, event) {
+		if matcher.Match(event) {
 			if err := server.Send(event); err != nil {
 				if server.Context().Err() != nil {
 					return io.EOF
@@ -155,7 +167,13 @@ func (s *memoryNSEServer) receiveEvent(
 }
 
 func (s *memoryNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
-	s.networkServiceEndpoints.Delete(nse.Name)
+	s.networkServiceEndpoints.delete(nse.Name)
+
+	if s.storage != nil {
+		if err := s.storage.DeleteNetworkServiceEndpoint(nse.Name); err != nil {
+			log.FromContext(ctx).Errorf("failed to delete %s from the storage: %s", nse.Name, err.Error())
+		}
+	}
 
 	nse.ExpirationTime = &timestamp.Timestamp{
 		Seconds: -1,
@@ -0,0 +1,227 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+const (
+	benchNSECount     = 10000
+	benchServiceCount = 1000
+	benchZoneCount    = 5000
+)
+
+type sendFuncNSEFindServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	send func(nse *registry.NetworkServiceEndpoint)
+}
+
+func (s *sendFuncNSEFindServer) Send(nse *registry.NetworkServiceEndpoint) error {
+	s.send(nse)
+	return nil
+}
+
+func (s *sendFuncNSEFindServer) Context() context.Context {
+	return s.ctx
+}
+
+func benchNSE(i int) *registry.NetworkServiceEndpoint {
+	service := fmt.Sprintf("ns-%d", i%benchServiceCount)
+	return &registry.NetworkServiceEndpoint{
+		Name:                fmt.Sprintf("nse-%d", i),
+		NetworkServiceNames: []string{service},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			service: {
+				Labels: map[string]string{
+					"zone": fmt.Sprintf("zone-%d", i%benchZoneCount),
+				},
+			},
+		},
+		Url: fmt.Sprintf("tcp://nse-%d", i),
+	}
+}
+
+// BenchmarkNetworkServiceEndpointRegistryServer_Find compares indexed queries with the full scan queries returning the
+// same network service endpoints
+func BenchmarkNetworkServiceEndpointRegistryServer_Find(b *testing.B) {
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer())
+	for i := 0; i < benchNSECount; i++ {
+		_, err := s.Register(context.Background(), benchNSE(i))
+		require.NoError(b, err)
+	}
+
+	for _, bench := range []struct {
+		name  string
+		query *registry.NetworkServiceEndpoint
+	}{
+		{
+			name:  "Url_FullScan",
+			query: &registry.NetworkServiceEndpoint{Url: "tcp://nse-4242"},
+		},
+		{
+			name:  "ExactName_Indexed",
+			query: &registry.NetworkServiceEndpoint{Name: matchutils.ExactName("nse-4242")},
+		},
+		{
+			name: "NetworkServiceSelector_FullScan",
+			query: &registry.NetworkServiceEndpoint{
+				NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+					"ns-42": {Labels: map[string]string{matchutils.SelectorKey: "zone"}},
+				},
+			},
+		},
+		{
+			name:  "NetworkServiceName_Indexed",
+			query: &registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"ns-42"}},
+		},
+		{
+			name: "LabelSelector_FullScan",
+			query: &registry.NetworkServiceEndpoint{
+				NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+					"ns-42": {Labels: map[string]string{matchutils.SelectorKey: "zone = zone-42"}},
+				},
+			},
+		},
+		{
+			name: "Label_Indexed",
+			query: &registry.NetworkServiceEndpoint{
+				NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+					"ns-42": {Labels: map[string]string{"zone": "zone-42"}},
+				},
+			},
+		},
+	} {
+		query := &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: bench.query,
+		}
+		b.Run(bench.name, func(b *testing.B) {
+			findServer := &sendFuncNSEFindServer{
+				ctx:  context.Background(),
+				send: func(*registry.NetworkServiceEndpoint) {},
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = s.Find(query, findServer)
+			}
+		})
+	}
+}
+
+// BenchmarkNetworkServiceEndpointRegistryServer_FindWatch compares event delivery to the watchers indexed by the
+// network service name with the delivery to the not indexed ones
+func BenchmarkNetworkServiceEndpointRegistryServer_FindWatch(b *testing.B) {
+	for _, bench := range []struct {
+		name  string
+		query func(service string) *registry.NetworkServiceEndpoint
+	}{
+		{
+			name: "Selector_NotIndexed",
+			query: func(service string) *registry.NetworkServiceEndpoint {
+				return &registry.NetworkServiceEndpoint{
+					NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+						service: {Labels: map[string]string{matchutils.SelectorKey: "zone"}},
+					},
+				}
+			},
+		},
+		{
+			name: "NetworkServiceName_Indexed",
+			query: func(service string) *registry.NetworkServiceEndpoint {
+				return &registry.NetworkServiceEndpoint{NetworkServiceNames: []string{service}}
+			},
+		},
+	} {
+		queryFunc := bench.query
+		b.Run(bench.name, func(b *testing.B) {
+			benchmarkFindWatch(b, queryFunc)
+		})
+	}
+}
+
+func benchmarkFindWatch(b *testing.B, queryFunc func(service string) *registry.NetworkServiceEndpoint) {
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer())
+	for i := 0; i < benchServiceCount; i++ {
+		_, err := s.Register(context.Background(), benchNSE(i))
+		require.NoError(b, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// Each watcher receives a single network service endpoint on start
+	var ready sync.WaitGroup
+	ready.Add(benchServiceCount)
+	receivedCh := make(chan struct{})
+	for i := 0; i < benchServiceCount; i++ {
+		var once sync.Once
+		var send func(*registry.NetworkServiceEndpoint)
+		if i == 0 {
+			send = func(*registry.NetworkServiceEndpoint) {
+				started := false
+				once.Do(func() {
+					started = true
+					ready.Done()
+				})
+				if !started {
+					receivedCh <- struct{}{}
+				}
+			}
+		} else {
+			send = func(*registry.NetworkServiceEndpoint) {
+				once.Do(ready.Done)
+			}
+		}
+
+		findServer := &sendFuncNSEFindServer{ctx: ctx, send: send}
+		query := &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: queryFunc(fmt.Sprintf("ns-%d", i)),
+			Watch:                  true,
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = s.Find(query, findServer)
+		}()
+	}
+	ready.Wait()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := s.Register(context.Background(), benchNSE(0))
+		require.NoError(b, err)
+		<-receivedCh
+	}
+}
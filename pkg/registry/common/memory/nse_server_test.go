@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
 func TestNetworkServiceEndpointRegistryServer_RegisterAndFind(t *testing.T) {
@@ -369,6 +371,125 @@ func TestNetworkServiceEndpointRegistryServer_ShouldReceiveAllUnregisters(t *tes
 	<-ctx.Done()
 }
 
+func TestNetworkServiceEndpointRegistryServer_FindByIndex(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer())
+
+	_, err := s.Register(context.Background(), createLabeledNSE1())
+	require.NoError(t, err)
+
+	_, err = s.Register(context.Background(), createLabeledNSE2())
+	require.NoError(t, err)
+
+	_, err = s.Register(context.Background(), createLabeledNSE3())
+	require.NoError(t, err)
+
+	find := func(query *registry.NetworkServiceEndpoint) (names []string) {
+		ch := make(chan *registry.NetworkServiceEndpoint, 10)
+		require.NoError(t, s.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: query,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(context.Background(), ch)))
+		close(ch)
+		for nse := range ch {
+			names = append(names, nse.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	byLabel := func(service, key, value string) *registry.NetworkServiceEndpoint {
+		return &registry.NetworkServiceEndpoint{
+			NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+				service: {
+					Labels: map[string]string{
+						key: value,
+					},
+				},
+			},
+		}
+	}
+
+	require.Equal(t, []string{"nse1", "nse2", "nse3"}, find(&registry.NetworkServiceEndpoint{Name: "nse"}))
+	require.Empty(t, find(&registry.NetworkServiceEndpoint{Name: matchutils.ExactName("nse")}))
+	require.Equal(t, []string{"nse2"}, find(&registry.NetworkServiceEndpoint{Name: matchutils.ExactName("nse2")}))
+	require.Equal(t, []string{"nse1", "nse2", "nse3"}, find(&registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"Service1"}}))
+	require.Equal(t, []string{"nse2"}, find(&registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"Service1", "Service2"}}))
+	require.Equal(t, []string{"nse2", "nse3"}, find(byLabel(matchutils.AnyNetworkService, "a", "b")))
+	require.Equal(t, []string{"nse3"}, find(byLabel("Service555", "a", "b")))
+
+	// Re-registered NSE should be reindexed
+	nse3 := createLabeledNSE3()
+	nse3.NetworkServiceNames = []string{"Service2"}
+	nse3.NetworkServiceLabels["Service555"].Labels["a"] = "c"
+	_, err = s.Register(context.Background(), nse3)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"nse1", "nse2"}, find(&registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"Service1"}}))
+	require.Equal(t, []string{"nse2", "nse3"}, find(&registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"Service2"}}))
+	require.Empty(t, find(byLabel("Service555", "a", "b")))
+	require.Equal(t, []string{"nse3"}, find(byLabel("Service555", "a", "c")))
+
+	// Unregistered NSE should be removed from the indexes
+	_, err = s.Unregister(context.Background(), createLabeledNSE2())
+	require.NoError(t, err)
+
+	require.Empty(t, find(&registry.NetworkServiceEndpoint{Name: matchutils.ExactName("nse2")}))
+	require.Equal(t, []string{"nse3"}, find(&registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"Service2"}}))
+	require.Equal(t, []string{"nse3"}, find(byLabel(matchutils.AnyNetworkService, "a", "c")))
+}
+
+func TestNetworkServiceEndpointRegistryServer_FindWatchByIndex(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer())
+
+	_, err := s.Register(context.Background(), createLabeledNSE2())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watch := func(query *registry.NetworkServiceEndpoint) <-chan *registry.NetworkServiceEndpoint {
+		ch := make(chan *registry.NetworkServiceEndpoint, 10)
+		go func() {
+			_ = s.Find(&registry.NetworkServiceEndpointQuery{
+				NetworkServiceEndpoint: query,
+				Watch:                  true,
+			}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+		}()
+		return ch
+	}
+
+	byExactName := watch(&registry.NetworkServiceEndpoint{Name: matchutils.ExactName("nse2")})
+	byService := watch(&registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"Service2"}})
+	bySubstring := watch(&registry.NetworkServiceEndpoint{Name: "nse"})
+
+	// Make sure all the watchers have started
+	for _, ch := range []<-chan *registry.NetworkServiceEndpoint{byExactName, byService, bySubstring} {
+		nse, recvErr := receiveNSE(ctx, ch)
+		require.NoError(t, recvErr)
+		require.Equal(t, "nse2", nse.Name)
+	}
+
+	_, err = s.Register(context.Background(), createLabeledNSE1())
+	require.NoError(t, err)
+	_, err = s.Register(context.Background(), createLabeledNSE3())
+	require.NoError(t, err)
+	_, err = s.Unregister(context.Background(), createLabeledNSE2())
+	require.NoError(t, err)
+
+	for _, name := range []string{"nse1", "nse3", "nse2"} {
+		nse, recvErr := receiveNSE(ctx, bySubstring)
+		require.NoError(t, recvErr)
+		require.Equal(t, name, nse.Name)
+	}
+	for _, ch := range []<-chan *registry.NetworkServiceEndpoint{byExactName, byService} {
+		nse, recvErr := receiveNSE(ctx, ch)
+		require.NoError(t, recvErr)
+		require.Equal(t, "nse2", nse.Name)
+		require.Equal(t, int64(-1), nse.ExpirationTime.Seconds)
+		require.Len(t, ch, 0)
+	}
+}
+
 func createLabeledNSE1() *registry.NetworkServiceEndpoint {
 	labels := map[string]*registry.NetworkServiceLabels{
 		"Service1": {
@@ -18,6 +18,7 @@ package memory
 
 type configurable interface {
 	setEventChannelSize(int)
+	setStorage(Storage)
 }
 
 // Option is memory registry configuration option
@@ -37,3 +38,11 @@ func WithEventChannelSize(l int) Option {
 		c.setEventChannelSize(l)
 	})
 }
+
+// WithStorage sets persistent storage for the registry entries: registered entries are stored and unregistered ones
+// are deleted. Stored entries are not loaded by the server, they are restored by the memory registry chain.
+func WithStorage(storage Storage) Option {
+	return applierFunc(func(c configurable) {
+		c.setStorage(storage)
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"path/filepath"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultSnapshotThreshold = 1000
+
+// Storage - persistent storage for the memory registry entries
+type Storage interface {
+	// LoadNetworkServices - returns all stored network services
+	LoadNetworkServices() ([]*registry.NetworkService, error)
+	// StoreNetworkService - stores the network service replacing the previous one with the same name
+	StoreNetworkService(ns *registry.NetworkService) error
+	// DeleteNetworkService - deletes the network service with the name
+	DeleteNetworkService(name string) error
+
+	// LoadNetworkServiceEndpoints - returns all stored network service endpoints
+	LoadNetworkServiceEndpoints() ([]*registry.NetworkServiceEndpoint, error)
+	// StoreNetworkServiceEndpoint - stores the network service endpoint replacing the previous one with the same name
+	StoreNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) error
+	// DeleteNetworkServiceEndpoint - deletes the network service endpoint with the name
+	DeleteNetworkServiceEndpoint(name string) error
+}
+
+// FileStorage - Storage keeping entries on the disk as a write-ahead log plus snapshots
+type FileStorage struct {
+	networkServices         *walStore
+	networkServiceEndpoints *walStore
+}
+
+// NewFileStorage - opens FileStorage in the directory, creates the directory if needed
+func NewFileStorage(dir string) (*FileStorage, error) {
+	networkServices, err := openWALStore(filepath.Join(dir, "ns"), defaultSnapshotThreshold)
+	if err != nil {
+		return nil, err
+	}
+	networkServiceEndpoints, err := openWALStore(filepath.Join(dir, "nse"), defaultSnapshotThreshold)
+	if err != nil {
+		_ = networkServices.close()
+		return nil, err
+	}
+	return &FileStorage{
+		networkServices:         networkServices,
+		networkServiceEndpoints: networkServiceEndpoints,
+	}, nil
+}
+
+// LoadNetworkServices - returns all stored network services
+func (s *FileStorage) LoadNetworkServices() (rv []*registry.NetworkService, err error) {
+	for name, bytes := range s.networkServices.load() {
+		ns := new(registry.NetworkService)
+		if err := proto.Unmarshal(bytes, ns); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal network service %s", name)
+		}
+		rv = append(rv, ns)
+	}
+	return rv, nil
+}
+
+// StoreNetworkService - stores the network service replacing the previous one with the same name
+func (s *FileStorage) StoreNetworkService(ns *registry.NetworkService) error {
+	bytes, err := proto.Marshal(ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal network service %s", ns.Name)
+	}
+	return s.networkServices.put(ns.Name, bytes)
+}
+
+// DeleteNetworkService - deletes the network service with the name
+func (s *FileStorage) DeleteNetworkService(name string) error {
+	return s.networkServices.delete(name)
+}
+
+// LoadNetworkServiceEndpoints - returns all stored network service endpoints
+func (s *FileStorage) LoadNetworkServiceEndpoints() (rv []*registry.NetworkServiceEndpoint, err error) {
+	for name, bytes := range s.networkServiceEndpoints.load() {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := proto.Unmarshal(bytes, nse); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal network service endpoint %s", name)
+		}
+		rv = append(rv, nse)
+	}
+	return rv, nil
+}
+
+// StoreNetworkServiceEndpoint - stores the network service endpoint replacing the previous one with the same name
+func (s *FileStorage) StoreNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) error {
+	bytes, err := proto.Marshal(nse)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.Name)
+	}
+	return s.networkServiceEndpoints.put(nse.Name, bytes)
+}
+
+// DeleteNetworkServiceEndpoint - deletes the network service endpoint with the name
+func (s *FileStorage) DeleteNetworkServiceEndpoint(name string) error {
+	return s.networkServiceEndpoints.delete(name)
+}
+
+// Close - closes the storage files
+func (s *FileStorage) Close() error {
+	nsErr := s.networkServices.close()
+	if err := s.networkServiceEndpoints.close(); err != nil {
+		return err
+	}
+	return nsErr
+}
+
+var _ Storage = (*FileStorage)(nil)
@@ -0,0 +1,119 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "memory")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func nseNames(t *testing.T, storage memory.Storage) (names []string) {
+	nses, err := storage.LoadNetworkServiceEndpoints()
+	require.NoError(t, err)
+	for _, nse := range nses {
+		names = append(names, nse.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestFileStorage(t *testing.T) {
+	dir := tempDir(t)
+
+	storage, err := memory.NewFileStorage(dir)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, storage.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: name}))
+	}
+	require.NoError(t, storage.DeleteNetworkServiceEndpoint("b"))
+	require.NoError(t, storage.StoreNetworkService(&registry.NetworkService{Name: "ns", Payload: "IP"}))
+	require.NoError(t, storage.Close())
+
+	// Reopen: snapshot is written on open
+	storage, err = memory.NewFileStorage(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, nseNames(t, storage))
+
+	nss, err := storage.LoadNetworkServices()
+	require.NoError(t, err)
+	require.Len(t, nss, 1)
+	require.Equal(t, "IP", nss[0].Payload)
+
+	// Changes after the snapshot go to the WAL
+	require.NoError(t, storage.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: "d"}))
+	require.NoError(t, storage.DeleteNetworkServiceEndpoint("a"))
+	require.NoError(t, storage.Close())
+
+	// Simulate a torn record
+	wal, err := os.OpenFile(filepath.Join(dir, "nse", "wal"), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = wal.WriteString(`{"key":"e","val`)
+	require.NoError(t, err)
+	require.NoError(t, wal.Close())
+
+	storage, err = memory.NewFileStorage(dir)
+	require.NoError(t, err)
+	defer func() { _ = storage.Close() }()
+	require.Equal(t, []string{"c", "d"}, nseNames(t, storage))
+}
+
+func TestNetworkServiceEndpointRegistryServer_Storage(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	storage, err := memory.NewFileStorage(tempDir(t))
+	require.NoError(t, err)
+	defer func() { _ = storage.Close() }()
+
+	s := next.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer(memory.WithStorage(storage)))
+
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:           "a",
+		ExpirationTime: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "b",
+	})
+	require.NoError(t, err)
+	_, err = s.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "b",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"a"}, nseNames(t, storage))
+}
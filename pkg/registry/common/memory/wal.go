@@ -0,0 +1,238 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	walFileName      = "wal"
+	snapshotFileName = "snapshot"
+)
+
+type walRecord struct {
+	Key    string `json:"key"`
+	Value  []byte `json:"value,omitempty"`
+	Delete bool   `json:"delete,omitempty"`
+}
+
+// walStore - key-value store persisted to the directory as a write-ahead log plus snapshots. Each change is appended
+// to the log and synced to the disk. When the log grows up to the snapshot threshold, the whole state is atomically
+// written to the snapshot and the log is truncated.
+type walStore struct {
+	dir               string
+	snapshotThreshold int
+	state             map[string][]byte
+	wal               *os.File
+	walRecords        int
+	mu                sync.Mutex
+}
+
+func openWALStore(dir string, snapshotThreshold int) (*walStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory %s", dir)
+	}
+
+	s := &walStore{
+		dir:               dir,
+		snapshotThreshold: snapshotThreshold,
+		state:             make(map[string][]byte),
+	}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.replayWAL(); err != nil {
+		return nil, err
+	}
+
+	wal, err := os.OpenFile(s.path(walFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", s.path(walFileName))
+	}
+	s.wal = wal
+
+	// Compact the log on open: it also drops a possibly torn last record
+	if err := s.snapshot(); err != nil {
+		_ = wal.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *walStore) load() map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rv := make(map[string][]byte, len(s.state))
+	for key, value := range s.state {
+		rv[key] = value
+	}
+	return rv
+}
+
+func (s *walStore) put(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.append(&walRecord{Key: key, Value: value}); err != nil {
+		return err
+	}
+	s.state[key] = value
+	return s.maybeSnapshot()
+}
+
+func (s *walStore) delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state[key]; !ok {
+		return nil
+	}
+	if err := s.append(&walRecord{Key: key, Delete: true}); err != nil {
+		return err
+	}
+	delete(s.state, key)
+	return s.maybeSnapshot()
+}
+
+func (s *walStore) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.wal.Close()
+}
+
+func (s *walStore) append(record *walRecord) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal WAL record")
+	}
+	if _, err := s.wal.Write(append(bytes, '\n')); err != nil {
+		return errors.Wrapf(err, "failed to write %s", s.wal.Name())
+	}
+	if err := s.wal.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to sync %s", s.wal.Name())
+	}
+	s.walRecords++
+	return nil
+}
+
+func (s *walStore) maybeSnapshot() error {
+	if s.walRecords < s.snapshotThreshold {
+		return nil
+	}
+	return s.snapshot()
+}
+
+func (s *walStore) snapshot() error {
+	bytes, err := json.Marshal(s.state)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal snapshot")
+	}
+
+	tmpFile, err := ioutil.TempFile(s.dir, snapshotFileName+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary snapshot file in %s", s.dir)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err = tmpFile.Write(bytes); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write %s", tmpFile.Name())
+	}
+	if err = tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to sync %s", tmpFile.Name())
+	}
+	if err = tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmpFile.Name())
+	}
+	if err = os.Rename(tmpFile.Name(), s.path(snapshotFileName)); err != nil {
+		return errors.Wrapf(err, "failed to save %s", s.path(snapshotFileName))
+	}
+
+	// Log records are already in the snapshot, so replaying them after a crash right here is harmless
+	if err = s.wal.Truncate(0); err != nil {
+		return errors.Wrapf(err, "failed to truncate %s", s.wal.Name())
+	}
+	s.walRecords = 0
+	return nil
+}
+
+func (s *walStore) loadSnapshot() error {
+	bytes, err := ioutil.ReadFile(s.path(snapshotFileName))
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return errors.Wrapf(err, "failed to read %s", s.path(snapshotFileName))
+	}
+	if err := json.Unmarshal(bytes, &s.state); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", s.path(snapshotFileName))
+	}
+	if s.state == nil {
+		s.state = make(map[string][]byte)
+	}
+	return nil
+}
+
+func (s *walStore) replayWAL() error {
+	file, err := os.Open(s.path(walFileName))
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return errors.Wrapf(err, "failed to open %s", s.path(walFileName))
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Last record without the trailing newline is torn
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", s.path(walFileName))
+		}
+
+		record := new(walRecord)
+		if err := json.Unmarshal(line, record); err != nil {
+			// Torn record, nothing is written after it
+			return nil
+		}
+		if record.Delete {
+			delete(s.state, record.Key)
+		} else {
+			s.state[record.Key] = record.Value
+		}
+	}
+}
+
+func (s *walStore) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
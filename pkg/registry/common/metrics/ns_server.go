@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+type metricsNSServer struct {
+	name    string
+	metrics *metrics.Metrics
+	names   namesSet
+}
+
+// NewNetworkServiceRegistryServer creates new NetworkServiceRegistryServer collecting metrics of the Register, Find and
+// Unregister calls, registered network services and watch subscribers labeled with the registry chain name
+func NewNetworkServiceRegistryServer(name string, options ...Option) registry.NetworkServiceRegistryServer {
+	s := &metricsNSServer{
+		name:    name,
+		metrics: metrics.Default(),
+	}
+	for _, o := range options {
+		o.apply(s)
+	}
+	return s
+}
+
+func (s *metricsNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	start := time.Now()
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	s.metrics.ObserveRegistryRequest(s.name, metrics.NetworkServiceKind, registerMethod, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, loaded := s.names.LoadOrStore(resp.Name, struct{}{}); !loaded {
+		s.metrics.Registered(s.name, metrics.NetworkServiceKind)
+	}
+
+	return resp, nil
+}
+
+func (s *metricsNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	start := time.Now()
+
+	if query.Watch {
+		s.metrics.WatchStarted(s.name, metrics.NetworkServiceKind)
+		defer s.metrics.WatchFinished(s.name, metrics.NetworkServiceKind)
+	}
+
+	err := next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+	s.metrics.ObserveRegistryRequest(s.name, metrics.NetworkServiceKind, findMethod, start, err)
+
+	return err
+}
+
+func (s *metricsNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	start := time.Now()
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	s.metrics.ObserveRegistryRequest(s.name, metrics.NetworkServiceKind, unregisterMethod, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, loaded := s.names.LoadAndDelete(ns.Name); loaded {
+		s.metrics.Unregistered(s.name, metrics.NetworkServiceKind)
+	}
+
+	return resp, nil
+}
+
+func (s *metricsNSServer) setMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+const (
+	registerMethod   = "Register"
+	findMethod       = "Find"
+	unregisterMethod = "Unregister"
+)
+
+type metricsNSEServer struct {
+	name    string
+	metrics *metrics.Metrics
+	names   namesSet
+}
+
+// NewNetworkServiceEndpointRegistryServer creates new NetworkServiceEndpointRegistryServer collecting metrics of the
+// Register, Find and Unregister calls, registered endpoints and watch subscribers labeled with the registry chain name
+func NewNetworkServiceEndpointRegistryServer(name string, options ...Option) registry.NetworkServiceEndpointRegistryServer {
+	s := &metricsNSEServer{
+		name:    name,
+		metrics: metrics.Default(),
+	}
+	for _, o := range options {
+		o.apply(s)
+	}
+	return s
+}
+
+func (s *metricsNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	start := time.Now()
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	s.metrics.ObserveRegistryRequest(s.name, metrics.EndpointKind, registerMethod, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, loaded := s.names.LoadOrStore(resp.Name, struct{}{}); !loaded {
+		s.metrics.Registered(s.name, metrics.EndpointKind)
+	}
+
+	return resp, nil
+}
+
+func (s *metricsNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	start := time.Now()
+
+	if query.Watch {
+		s.metrics.WatchStarted(s.name, metrics.EndpointKind)
+		defer s.metrics.WatchFinished(s.name, metrics.EndpointKind)
+	}
+
+	err := next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+	s.metrics.ObserveRegistryRequest(s.name, metrics.EndpointKind, findMethod, start, err)
+
+	return err
+}
+
+func (s *metricsNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	start := time.Now()
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	s.metrics.ObserveRegistryRequest(s.name, metrics.EndpointKind, unregisterMethod, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, loaded := s.names.LoadAndDelete(nse.Name); loaded {
+		s.metrics.Unregistered(s.name, metrics.EndpointKind)
+	}
+
+	return resp, nil
+}
+
+func (s *metricsNSEServer) setMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
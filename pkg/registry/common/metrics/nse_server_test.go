@@ -0,0 +1,150 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/metrics"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	metricstools "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+// gather - returns metrics with the name by the "method" label value
+func gather(t *testing.T, reg *prometheus.Registry, name string) map[string]*dto.Metric {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	rv := make(map[string]*dto.Metric)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			key := ""
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "method" {
+					key = label.GetValue()
+				}
+			}
+			rv[key] = metric
+		}
+	}
+	return rv
+}
+
+func gauge(t *testing.T, reg *prometheus.Registry, name string) float64 {
+	return gather(t, reg, name)[""].GetGauge().GetValue()
+}
+
+func TestNetworkServiceEndpointRegistryServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	reg := prometheus.NewRegistry()
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		metrics.NewNetworkServiceEndpointRegistryServer("registry", metrics.WithMetrics(metricstools.New(reg))),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+	require.Equal(t, 2., gauge(t, reg, "nsm_registry_registered"))
+
+	// Refresh
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Equal(t, 2., gauge(t, reg, "nsm_registry_registered"))
+
+	_, err = s.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Equal(t, 1., gauge(t, reg, "nsm_registry_registered"))
+
+	requests := gather(t, reg, "nsm_registry_requests_total")
+	require.Equal(t, 3., requests["Register"].GetCounter().GetValue())
+	require.Equal(t, 1., requests["Unregister"].GetCounter().GetValue())
+}
+
+func TestNetworkServiceEndpointRegistryServer_Watch(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	reg := prometheus.NewRegistry()
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		metrics.NewNetworkServiceEndpointRegistryServer("registry", metrics.WithMetrics(metricstools.New(reg))),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+			Watch:                  true,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, make(chan *registry.NetworkServiceEndpoint, 10)))
+	}()
+
+	require.Eventually(t, func() bool {
+		return gauge(t, reg, "nsm_registry_watch_subscribers") == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-errCh
+
+	require.Equal(t, 0., gauge(t, reg, "nsm_registry_watch_subscribers"))
+	require.Equal(t, 1., gather(t, reg, "nsm_registry_requests_total")["Find"].GetCounter().GetValue())
+}
+
+func TestNetworkServiceEndpointRegistryServer_Expire(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	reg := prometheus.NewRegistry()
+	m := metricstools.New(reg)
+
+	ctx, cancel := context.WithCancel(metricstools.WithMetrics(context.Background(), m))
+	defer cancel()
+
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		expire.NewNetworkServiceEndpointRegistryServer(ctx, 100*time.Millisecond),
+		metrics.NewNetworkServiceEndpointRegistryServer("registry", metrics.WithMetrics(m)),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Equal(t, 1., gauge(t, reg, "nsm_registry_registered"))
+
+	require.Eventually(t, func() bool {
+		return gauge(t, reg, "nsm_registry_registered") == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return gather(t, reg, "nsm_registry_expired_total")[""].GetCounter().GetValue() == 1
+	}, time.Second, 10*time.Millisecond)
+}
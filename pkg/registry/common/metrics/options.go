@@ -0,0 +1,43 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+type configurable interface {
+	setMetrics(*metrics.Metrics)
+}
+
+// Option is metrics registry server configuration option
+type Option interface {
+	apply(configurable)
+}
+
+type applierFunc func(configurable)
+
+func (f applierFunc) apply(c configurable) {
+	f(c)
+}
+
+// WithMetrics sets metrics to collect, default is metrics registered with the metrics.Registry()
+func WithMetrics(m *metrics.Metrics) Option {
+	return applierFunc(func(c configurable) {
+		c.setMetrics(m)
+	})
+}
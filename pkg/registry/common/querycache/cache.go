@@ -21,17 +21,21 @@ import (
 	"sync"
 	"time"
 
-	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/protobuf/proto"
 )
 
 type cache struct {
 	expireTimeout time.Duration
+	extendOnLoad  bool
 	entries       cacheEntryMap
 }
 
-func newCache(ctx context.Context, opts ...Option) *cache {
+// newCache - returns a new cache, if extendOnLoad is true entry expiration time is extended on each Load, otherwise
+// entry expires in the expire timeout after it is stored
+func newCache(ctx context.Context, extendOnLoad bool, opts ...Option) *cache {
 	c := &cache{
 		expireTimeout: time.Minute,
+		extendOnLoad:  extendOnLoad,
 	}
 
 	for _, opt := range opts {
@@ -63,10 +67,10 @@ func newCache(ctx context.Context, opts ...Option) *cache {
 	return c
 }
 
-func (c *cache) LoadOrStore(key string, nse *registry.NetworkServiceEndpoint, cancel context.CancelFunc) (*cacheEntry, bool) {
+func (c *cache) LoadOrStore(key string, value proto.Message, cancel context.CancelFunc) (*cacheEntry, bool) {
 	var once sync.Once
 	return c.entries.LoadOrStore(key, &cacheEntry{
-		nse:            nse,
+		value:          value,
 		expirationTime: time.Now().Add(c.expireTimeout),
 		cleanup: func() {
 			once.Do(func() {
@@ -77,7 +81,7 @@ func (c *cache) LoadOrStore(key string, nse *registry.NetworkServiceEndpoint, ca
 	})
 }
 
-func (c *cache) Load(key string) (*registry.NetworkServiceEndpoint, bool) {
+func (c *cache) Load(key string) (proto.Message, bool) {
 	e, ok := c.entries.Load(key)
 	if !ok {
 		return nil, false
@@ -91,23 +95,39 @@ func (c *cache) Load(key string) (*registry.NetworkServiceEndpoint, bool) {
 		return nil, false
 	}
 
-	e.expirationTime = time.Now().Add(c.expireTimeout)
+	if c.extendOnLoad {
+		e.expirationTime = time.Now().Add(c.expireTimeout)
+	}
+
+	return e.value, true
+}
+
+// InvalidateIf - removes from the cache all entries matching the predicate and stops their updates
+func (c *cache) InvalidateIf(predicate func(value proto.Message) bool) {
+	c.entries.Range(func(_ string, e *cacheEntry) bool {
+		e.lock.Lock()
+		defer e.lock.Unlock()
 
-	return e.nse, true
+		if predicate(e.value) {
+			e.cleanup()
+		}
+
+		return true
+	})
 }
 
 type cacheEntry struct {
-	nse            *registry.NetworkServiceEndpoint
+	value          proto.Message
 	expirationTime time.Time
 	lock           sync.Mutex
 	cleanup        func()
 }
 
-func (e *cacheEntry) Update(nse *registry.NetworkServiceEndpoint) {
+func (e *cacheEntry) Update(value proto.Message) {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	e.nse = nse
+	e.value = value
 }
 
 func (e *cacheEntry) Cleanup() {
@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycache
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+type queryCacheNSClient struct {
+	ctx   context.Context
+	cache *cache
+}
+
+// NewNetworkServiceRegistryClient creates new querycache NS registry client that caches all resolved NSs. Cached NSs
+// are updated by watching them in the registry. Registry doesn't notify watchers about NS deletion, so NSs are also
+// dropped from the cache on Unregister, on the watch end and in the expire timeout after they are cached. If NSs are
+// unregistered not through the client, use NewNetworkServiceRegistryServer to drop them from the cache.
+func NewNetworkServiceRegistryClient(ctx context.Context, opts ...Option) registry.NetworkServiceRegistryClient {
+	return &queryCacheNSClient{
+		ctx:   ctx,
+		cache: newCache(ctx, false, opts...),
+	}
+}
+
+func (q *queryCacheNSClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	return next.NetworkServiceRegistryClient(ctx).Register(ctx, ns, opts...)
+}
+
+func (q *queryCacheNSClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	if query.Watch {
+		return next.NetworkServiceRegistryClient(ctx).Find(ctx, query, opts...)
+	}
+
+	if client, ok := q.findInCache(ctx, query); ok {
+		metrics.FromContext(q.ctx).QueryCacheHit()
+		return client, nil
+	}
+	metrics.FromContext(q.ctx).QueryCacheMiss()
+
+	client, err := next.NetworkServiceRegistryClient(ctx).Find(ctx, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	nss := registry.ReadNetworkServiceList(client)
+
+	resultCh := make(chan *registry.NetworkService, len(nss))
+	for _, ns := range nss {
+		resultCh <- ns
+	}
+	close(resultCh)
+
+	// Only the queries resolved to the single NS by name are cached
+	if len(nss) == 1 && nss[0].Name == query.GetNetworkService().GetName() {
+		q.storeInCache(ctx, query, nss[0], opts...)
+	}
+
+	return streamchannel.NewNetworkServiceFindClient(ctx, resultCh), nil
+}
+
+func (q *queryCacheNSClient) findInCache(ctx context.Context, query *registry.NetworkServiceQuery) (registry.NetworkServiceRegistry_FindClient, bool) {
+	value, ok := q.cache.Load(query.String())
+	if !ok {
+		return nil, false
+	}
+
+	// Cached NS can be updated so it doesn't match the query anymore
+	ns := value.(*registry.NetworkService)
+	if !matchutils.MatchNetworkServices(query.NetworkService, ns) {
+		return nil, false
+	}
+
+	resultCh := make(chan *registry.NetworkService, 1)
+	resultCh <- ns.Clone()
+	close(resultCh)
+
+	return streamchannel.NewNetworkServiceFindClient(ctx, resultCh), true
+}
+
+func (q *queryCacheNSClient) storeInCache(ctx context.Context, query *registry.NetworkServiceQuery, ns *registry.NetworkService, opts ...grpc.CallOption) {
+	key := query.String()
+
+	findCtx, cancel := context.WithCancel(q.ctx)
+
+	entry, loaded := q.cache.LoadOrStore(key, ns, cancel)
+	if loaded {
+		cancel()
+		return
+	}
+
+	go func() {
+		defer entry.Cleanup()
+
+		watchQuery := proto.Clone(query).(*registry.NetworkServiceQuery)
+		watchQuery.Watch = true
+
+		stream, err := next.NetworkServiceRegistryClient(ctx).Find(findCtx, watchQuery, opts...)
+		if err != nil {
+			return
+		}
+
+		for event, err := stream.Recv(); err == nil; event, err = stream.Recv() {
+			if event.Name != ns.Name {
+				continue
+			}
+
+			entry.Update(event)
+		}
+	}()
+}
+
+func (q *queryCacheNSClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	invalidateNS(q.cache, ns.Name)
+
+	return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, ns, opts...)
+}
+
+// invalidateNS - drops the NS from the cache
+func invalidateNS(c *cache, name string) {
+	c.InvalidateIf(func(value proto.Message) bool {
+		return value.(*registry.NetworkService).Name == name
+	})
+}
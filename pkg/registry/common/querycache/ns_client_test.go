@@ -0,0 +1,167 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycache_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/querycache"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+const (
+	nsName   = "ns"
+	payload1 = "IP"
+	payload2 = "ETHERNET"
+)
+
+func testNSQuery(name string) *registry.NetworkServiceQuery {
+	return &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{
+			Name: name,
+		},
+	}
+}
+
+func findNS(ctx context.Context, c registry.NetworkServiceRegistryClient, name string) (*registry.NetworkService, error) {
+	stream, err := c.Find(ctx, testNSQuery(name))
+	if err != nil {
+		return nil, err
+	}
+	return stream.Recv()
+}
+
+func Test_QueryCacheNSClient_ShouldCacheNSs(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mem := memory.NewNetworkServiceRegistryServer()
+
+	failureClient := new(failureNSClient)
+	c := next.NewNetworkServiceRegistryClient(
+		querycache.NewNetworkServiceRegistryClient(ctx, querycache.WithExpireTimeout(time.Minute)),
+		failureClient,
+		adapters.NetworkServiceServerToClient(mem),
+	)
+
+	reg, err := mem.Register(ctx, &registry.NetworkService{
+		Name:    nsName,
+		Payload: payload1,
+	})
+	require.NoError(t, err)
+
+	// Goroutines should be cleaned up on NS unregister
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	// 1. Find from memory
+	ns, err := findNS(ctx, c, nsName)
+	require.NoError(t, err)
+	require.Equal(t, payload1, ns.Payload)
+
+	// 2. Find from cache
+	atomic.StoreInt32(&failureClient.shouldFail, 1)
+
+	require.Eventually(t, func() bool {
+		ns, err = findNS(ctx, c, nsName)
+		return err == nil && ns.Payload == payload1
+	}, 100*time.Millisecond, time.Millisecond)
+
+	// 3. Update NS in memory
+	reg.Payload = payload2
+
+	_, err = mem.Register(ctx, reg)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		ns, err = findNS(ctx, c, nsName)
+		return err == nil && ns.Payload == payload2
+	}, 100*time.Millisecond, time.Millisecond)
+
+	// 4. Unregister NS
+	_, err = c.Unregister(ctx, reg)
+	require.NoError(t, err)
+
+	_, err = findNS(ctx, c, nsName)
+	require.Error(t, err)
+}
+
+func Test_QueryCacheNSClient_ShouldNotCacheNotMatchingQueries(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mem := memory.NewNetworkServiceRegistryServer()
+
+	failureClient := new(failureNSClient)
+	c := next.NewNetworkServiceRegistryClient(
+		querycache.NewNetworkServiceRegistryClient(ctx),
+		failureClient,
+		adapters.NetworkServiceServerToClient(mem),
+	)
+
+	_, err := mem.Register(ctx, &registry.NetworkService{
+		Name: nsName,
+	})
+	require.NoError(t, err)
+
+	// 1. Find all from memory
+	_, err = findNS(ctx, c, "")
+	require.NoError(t, err)
+
+	// 2. Nothing is cached
+	atomic.StoreInt32(&failureClient.shouldFail, 1)
+
+	_, err = findNS(ctx, c, "")
+	require.Error(t, err)
+
+	_, err = findNS(ctx, c, nsName)
+	require.Error(t, err)
+}
+
+type failureNSClient struct {
+	shouldFail int32
+}
+
+func (c *failureNSClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	return next.NetworkServiceRegistryClient(ctx).Register(ctx, ns, opts...)
+}
+
+func (c *failureNSClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	if atomic.LoadInt32(&c.shouldFail) == 1 && !query.Watch {
+		return nil, errors.New("find error")
+	}
+	return next.NetworkServiceRegistryClient(ctx).Find(ctx, query, opts...)
+}
+
+func (c *failureNSClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, ns, opts...)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycache
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type queryCacheNSServer struct {
+	cache *cache
+}
+
+// NewNetworkServiceRegistryServer creates new querycache NS registry server dropping the NSs cached by the client from
+// the cache on Unregister. It should be used in the chain handling NS Unregister when the client is used only to
+// resolve NSs, so Unregister doesn't go through it. client should be created with NewNetworkServiceRegistryClient.
+func NewNetworkServiceRegistryServer(client registry.NetworkServiceRegistryClient) registry.NetworkServiceRegistryServer {
+	c, ok := client.(*queryCacheNSClient)
+	if !ok {
+		panic("client should be created with querycache.NewNetworkServiceRegistryClient")
+	}
+	return &queryCacheNSServer{
+		cache: c.cache,
+	}
+}
+
+func (s *queryCacheNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *queryCacheNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *queryCacheNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+
+	// NS can be cached again while it is being unregistered, so it is dropped after the Unregister
+	invalidateNS(s.cache, ns.Name)
+
+	return resp, err
+}
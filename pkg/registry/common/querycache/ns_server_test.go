@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycache_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/querycache"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+func Test_QueryCacheNSServer_ShouldInvalidateOnUnregister(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mem := memory.NewNetworkServiceRegistryServer()
+
+	cacheClient := querycache.NewNetworkServiceRegistryClient(ctx, querycache.WithExpireTimeout(time.Minute))
+
+	failureClient := new(failureNSClient)
+	c := next.NewNetworkServiceRegistryClient(
+		cacheClient,
+		failureClient,
+		adapters.NetworkServiceServerToClient(mem),
+	)
+	s := next.NewNetworkServiceRegistryServer(
+		querycache.NewNetworkServiceRegistryServer(cacheClient),
+		mem,
+	)
+
+	reg, err := s.Register(ctx, &registry.NetworkService{
+		Name:    nsName,
+		Payload: payload1,
+	})
+	require.NoError(t, err)
+
+	// 1. Find from memory
+	_, err = findNS(ctx, c, nsName)
+	require.NoError(t, err)
+
+	// 2. Find from cache
+	atomic.StoreInt32(&failureClient.shouldFail, 1)
+
+	require.Eventually(t, func() bool {
+		_, err = findNS(ctx, c, nsName)
+		return err == nil
+	}, 100*time.Millisecond, time.Millisecond)
+
+	// 3. Unregister NS not through the client
+	_, err = s.Unregister(ctx, reg)
+	require.NoError(t, err)
+
+	_, err = findNS(ctx, c, nsName)
+	require.Error(t, err)
+}
+
+func Test_QueryCacheNSClient_ShouldNotExtendOnFind(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mem := memory.NewNetworkServiceRegistryServer()
+
+	failureClient := new(failureNSClient)
+	c := next.NewNetworkServiceRegistryClient(
+		querycache.NewNetworkServiceRegistryClient(ctx, querycache.WithExpireTimeout(100*time.Millisecond)),
+		failureClient,
+		adapters.NetworkServiceServerToClient(mem),
+	)
+
+	_, err := mem.Register(ctx, &registry.NetworkService{
+		Name: nsName,
+	})
+	require.NoError(t, err)
+
+	_, err = findNS(ctx, c, nsName)
+	require.NoError(t, err)
+
+	atomic.StoreInt32(&failureClient.shouldFail, 1)
+
+	// Cache hits don't keep the NS in the cache
+	require.Eventually(t, func() bool {
+		_, err = findNS(ctx, c, nsName)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
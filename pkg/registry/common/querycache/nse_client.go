@@ -27,6 +27,8 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
 )
 
 type queryCacheNSEClient struct {
@@ -38,7 +40,7 @@ type queryCacheNSEClient struct {
 func NewClient(ctx context.Context, opts ...Option) registry.NetworkServiceEndpointRegistryClient {
 	return &queryCacheNSEClient{
 		ctx:   ctx,
-		cache: newCache(ctx, opts...),
+		cache: newCache(ctx, true, opts...),
 	}
 }
 
@@ -51,9 +53,11 @@ func (q *queryCacheNSEClient) Find(ctx context.Context, query *registry.NetworkS
 		return next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, query, opts...)
 	}
 
-	if client, ok := q.findInCache(ctx, query.String()); ok {
+	if client, ok := q.findInCache(ctx, query); ok {
+		metrics.FromContext(q.ctx).QueryCacheHit()
 		return client, nil
 	}
+	metrics.FromContext(q.ctx).QueryCacheMiss()
 
 	client, err := next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, query, opts...)
 	if err != nil {
@@ -72,12 +76,18 @@ func (q *queryCacheNSEClient) Find(ctx context.Context, query *registry.NetworkS
 	return streamchannel.NewNetworkServiceEndpointFindClient(ctx, resultCh), nil
 }
 
-func (q *queryCacheNSEClient) findInCache(ctx context.Context, key string) (registry.NetworkServiceEndpointRegistry_FindClient, bool) {
-	nse, ok := q.cache.Load(key)
+func (q *queryCacheNSEClient) findInCache(ctx context.Context, query *registry.NetworkServiceEndpointQuery) (registry.NetworkServiceEndpointRegistry_FindClient, bool) {
+	value, ok := q.cache.Load(query.String())
 	if !ok {
 		return nil, false
 	}
 
+	// Cached NSE can be updated so it doesn't match the query anymore
+	nse := value.(*registry.NetworkServiceEndpoint)
+	if !matchutils.MatchNetworkServiceEndpoints(query.NetworkServiceEndpoint, nse) {
+		return nil, false
+	}
+
 	resultCh := make(chan *registry.NetworkServiceEndpoint, 1)
 	resultCh <- nse.Clone()
 	close(resultCh)
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultNSExpiryDuration = time.Minute
+
+type refreshNSClient struct {
+	chainContext          context.Context
+	nsCancels             cancelsMap
+	defaultExpiryDuration time.Duration
+}
+
+// NewNetworkServiceRegistryClient creates new NetworkServiceRegistryClient that will periodically re-register
+// registered NSs, so they are restored after the registry restart
+func NewNetworkServiceRegistryClient(options ...Option) registry.NetworkServiceRegistryClient {
+	c := &refreshNSClient{
+		defaultExpiryDuration: defaultNSExpiryDuration,
+		chainContext:          context.Background(),
+	}
+
+	for _, o := range options {
+		o.apply(c)
+	}
+
+	return c
+}
+
+func (c *refreshNSClient) setDefaultExpiryDuration(duration time.Duration) {
+	c.defaultExpiryDuration = duration
+}
+
+func (c *refreshNSClient) setChainContext(ctx context.Context) {
+	c.chainContext = ctx
+}
+
+func (c *refreshNSClient) startRefresh(
+	ctx context.Context,
+	client registry.NetworkServiceRegistryClient,
+	ns *registry.NetworkService,
+) {
+	logger := log.FromContext(ctx).WithField("refreshNSClient", "startRefresh")
+	clk := clock.FromContext(ctx)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-clk.After(2 * c.defaultExpiryDuration / 3):
+				// Registry can be temporary unavailable during the restart, so we keep refreshing on errors
+				if _, err := client.Register(ctx, ns.Clone()); err != nil {
+					logger.Errorf("failed to update registration: %s", err.Error())
+				}
+			}
+		}
+	}()
+}
+
+func (c *refreshNSClient) Register(
+	ctx context.Context,
+	ns *registry.NetworkService,
+	opts ...grpc.CallOption,
+) (*registry.NetworkService, error) {
+	refreshNS := ns.Clone()
+
+	nextClient := next.NetworkServiceRegistryClient(ctx)
+
+	resp, err := nextClient.Register(ctx, ns, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if cancel, ok := c.nsCancels.Load(resp.Name); ok {
+		cancel()
+	}
+
+	refreshNS.Name = resp.Name
+
+	ctx, cancel := context.WithCancel(c.chainContext)
+	c.nsCancels.Store(resp.Name, cancel)
+
+	c.startRefresh(ctx, nextClient, refreshNS)
+
+	return resp, err
+}
+
+func (c *refreshNSClient) Find(
+	ctx context.Context,
+	query *registry.NetworkServiceQuery,
+	opts ...grpc.CallOption,
+) (registry.NetworkServiceRegistry_FindClient, error) {
+	return next.NetworkServiceRegistryClient(ctx).Find(ctx, query, opts...)
+}
+
+func (c *refreshNSClient) Unregister(
+	ctx context.Context,
+	ns *registry.NetworkService,
+	opts ...grpc.CallOption,
+) (*empty.Empty, error) {
+	if cancel, ok := c.nsCancels.Load(ns.Name); ok {
+		cancel()
+	}
+	c.nsCancels.Delete(ns.Name)
+
+	return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, ns, opts...)
+}
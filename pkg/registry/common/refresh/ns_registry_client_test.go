@@ -0,0 +1,144 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func TestNewNetworkServiceRegistryClient(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	countClient := new(requestCountNSClient)
+	client := next.NewNetworkServiceRegistryClient(
+		refresh.NewNetworkServiceRegistryClient(refresh.WithDefaultExpiryDuration(testExpiryDuration)),
+		countClient,
+	)
+
+	_, err := client.Register(context.Background(), &registry.NetworkService{
+		Name: "ns-1",
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) > 2
+	}, time.Second, testExpiryDuration/4)
+
+	_, err = client.Unregister(context.Background(), &registry.NetworkService{
+		Name: "ns-1",
+	})
+	require.NoError(t, err)
+
+	count := atomic.LoadInt32(&countClient.requestCount)
+	require.Never(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) > count
+	}, testExpiryDuration, testExpiryDuration/4)
+}
+
+func TestRefreshNSClient_ShouldKeepRefreshing_OnError(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	countClient := &requestCountNSClient{
+		failFrom: 2,
+		failTo:   3,
+	}
+	client := next.NewNetworkServiceRegistryClient(
+		refresh.NewNetworkServiceRegistryClient(
+			refresh.WithChainContext(ctx),
+			refresh.WithDefaultExpiryDuration(testExpiryDuration),
+		),
+		countClient,
+	)
+
+	_, err := client.Register(ctx, &registry.NetworkService{
+		Name: "ns-1",
+	})
+	require.NoError(t, err)
+
+	// Registrations 2 and 3 fail, refresh should go on
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) > 4
+	}, time.Second, testExpiryDuration/4)
+}
+
+func TestRefreshNSClient_Clock(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.NewMock()
+	ctx = clock.WithClock(ctx, clockMock)
+
+	countClient := new(requestCountNSClient)
+	client := next.NewNetworkServiceRegistryClient(
+		refresh.NewNetworkServiceRegistryClient(refresh.WithChainContext(ctx)),
+		countClient,
+	)
+
+	_, err := client.Register(ctx, &registry.NetworkService{
+		Name: "ns-1",
+	})
+	require.NoError(t, err)
+
+	require.Never(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Minute)
+		return atomic.LoadInt32(&countClient.requestCount) > 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+type requestCountNSClient struct {
+	requestCount     int32
+	failFrom, failTo int32
+
+	registry.NetworkServiceRegistryClient
+}
+
+func (t *requestCountNSClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	if count := atomic.AddInt32(&t.requestCount, 1); count >= t.failFrom && count <= t.failTo {
+		return nil, errors.New("registry is unavailable")
+	}
+
+	return next.NetworkServiceRegistryClient(ctx).Register(ctx, ns, opts...)
+}
+
+func (t *requestCountNSClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, ns, opts...)
+}
@@ -52,6 +52,14 @@ func NewNetworkServiceEndpointRegistryClient(options ...Option) registry.Network
 	return c
 }
 
+func (c *refreshNSEClient) setDefaultExpiryDuration(duration time.Duration) {
+	c.defaultExpiryDuration = duration
+}
+
+func (c *refreshNSEClient) setChainContext(ctx context.Context) {
+	c.chainContext = ctx
+}
+
 func (c *refreshNSEClient) startRefresh(
 	ctx context.Context,
 	client registry.NetworkServiceEndpointRegistryClient,
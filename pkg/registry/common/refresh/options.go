@@ -21,27 +21,33 @@ import (
 	"time"
 )
 
+type configurable interface {
+	setDefaultExpiryDuration(time.Duration)
+	setChainContext(context.Context)
+}
+
 // Option is expire registry configuration option
 type Option interface {
-	apply(client *refreshNSEClient)
+	apply(configurable)
 }
 
-type applierFunc func(*refreshNSEClient)
+type applierFunc func(configurable)
 
-func (f applierFunc) apply(c *refreshNSEClient) {
+func (f applierFunc) apply(c configurable) {
 	f(c)
 }
 
-// WithDefaultExpiryDuration sets a default expiration_time if it is nil on NSE registration
+// WithDefaultExpiryDuration sets a default expiration_time if it is nil on NSE registration. Network services have
+// no expiration time, so they are re-registered each 2/3 of this duration.
 func WithDefaultExpiryDuration(duration time.Duration) Option {
-	return applierFunc(func(c *refreshNSEClient) {
-		c.defaultExpiryDuration = duration
+	return applierFunc(func(c configurable) {
+		c.setDefaultExpiryDuration(duration)
 	})
 }
 
 // WithChainContext sets a chain context
 func WithChainContext(ctx context.Context) Option {
-	return applierFunc(func(c *refreshNSEClient) {
-		c.chainContext = ctx
+	return applierFunc(func(c configurable) {
+		c.setChainContext(ctx)
 	})
 }
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+)
+
+// withReplica - marks outgoing request as sent by the peer registry
+func withReplica(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, replicaKey, "true")
+}
+
+// isReplica - returns true if incoming request has been sent by the peer registry, returns an error if the request is
+// marked as sent by the peer registry, but the peer is not authenticated
+func isReplica(ctx context.Context, isPeer PeerAuthFunc) (bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(replicaKey)) == 0 {
+		return false, nil
+	}
+	if !isPeer(ctx) {
+		return false, status.Error(codes.PermissionDenied, "request is marked as sent by the peer registry, but the peer is not trusted")
+	}
+	return true, nil
+}
+
+// peerID - returns SPIFFE ID of the peer TLS certificate
+func peerID(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	var tlsInfo credentials.TLSInfo
+	switch authInfo := p.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		tlsInfo = authInfo
+	case *credentials.TLSInfo:
+		tlsInfo = *authInfo
+	default:
+		return "", false
+	}
+	if len(tlsInfo.State.PeerCertificates) == 0 {
+		return "", false
+	}
+	spiffeID, err := x509svid.IDFromCert(tlsInfo.State.PeerCertificates[0])
+	if err != nil {
+		return "", false
+	}
+	return spiffeID.String(), true
+}
+
+// detach - returns context for the local server with values taken from the chain context, so the local server doesn't
+// continue to the rest of the chain after its last element
+func detach(ctx, chainCtx context.Context) context.Context {
+	return extend.WithValuesFromContext(ctx, chainCtx)
+}
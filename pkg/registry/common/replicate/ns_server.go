@@ -0,0 +1,210 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type replicateNSServer struct {
+	ctx    context.Context
+	local  registry.NetworkServiceRegistryServer
+	peers  []*peer
+	isPeer PeerAuthFunc
+
+	mu      sync.Mutex
+	entries map[string]*registry.NetworkService
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer replicating Register/Unregister to the
+// peer registries. Network services have no expiration time, so requests received from the peers are applied to the
+// local server as is. Local server should contain all the elements keeping the registry state (expire, memory, etc.).
+//
+// Should be placed after the `serialize` and before the `expire`.
+func NewNetworkServiceRegistryServer(
+	ctx context.Context,
+	local registry.NetworkServiceRegistryServer,
+	options ...Option,
+) registry.NetworkServiceRegistryServer {
+	o := newOptions(options)
+
+	s := &replicateNSServer{
+		ctx:     ctx,
+		local:   local,
+		peers:   newPeers(ctx, o),
+		isPeer:  o.isPeer,
+		entries: make(map[string]*registry.NetworkService),
+	}
+	if len(s.peers) > 0 {
+		go s.syncLoop(o.syncInterval)
+	}
+
+	return s
+}
+
+func (s *replicateNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	replica, err := isReplica(ctx, s.isPeer)
+	if err != nil {
+		return nil, err
+	}
+	if replica {
+		return s.registerReplica(ctx, ns)
+	}
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.entries[resp.Name] = resp.Clone()
+	s.mu.Unlock()
+
+	s.replicateRegister(resp.Clone())
+
+	return resp, nil
+}
+
+func (s *replicateNSServer) registerReplica(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[ns.Name]
+	s.mu.Unlock()
+
+	if ok && proto.Equal(entry, ns) {
+		return entry.Clone(), nil
+	}
+
+	resp, err := s.local.Register(detach(ctx, s.ctx), ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.entries[resp.Name] = resp.Clone()
+	s.mu.Unlock()
+
+	return resp, nil
+}
+
+func (s *replicateNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *replicateNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	replica, err := isReplica(ctx, s.isPeer)
+	if err != nil {
+		return nil, err
+	}
+	if replica {
+		return s.unregisterReplica(ctx, ns)
+	}
+
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.entries, ns.Name)
+	s.mu.Unlock()
+
+	s.replicateUnregister(ns.Clone())
+
+	return resp, nil
+}
+
+func (s *replicateNSServer) unregisterReplica(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	s.mu.Lock()
+	_, ok := s.entries[ns.Name]
+	s.mu.Unlock()
+
+	if !ok {
+		return new(empty.Empty), nil
+	}
+
+	resp, err := s.local.Unregister(detach(ctx, s.ctx), ns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.entries, ns.Name)
+	s.mu.Unlock()
+
+	return resp, nil
+}
+
+func (s *replicateNSServer) replicateRegister(ns *registry.NetworkService) {
+	for _, p := range s.peers {
+		p.enqueue(ns.Name, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+			_, err := registry.NewNetworkServiceRegistryClient(cc).Register(ctx, ns.Clone())
+			return err
+		})
+	}
+}
+
+func (s *replicateNSServer) replicateUnregister(ns *registry.NetworkService) {
+	for _, p := range s.peers {
+		p.enqueue(ns.Name, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+			_, err := registry.NewNetworkServiceRegistryClient(cc).Unregister(ctx, ns.Clone())
+			return err
+		})
+	}
+}
+
+func (s *replicateNSServer) syncLoop(syncInterval time.Duration) {
+	ticker := clock.FromContext(s.ctx).Ticker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C():
+			s.sync()
+		}
+	}
+}
+
+// sync - pushes all known entries to the peers
+func (s *replicateNSServer) sync() {
+	s.mu.Lock()
+	nss := make([]*registry.NetworkService, 0, len(s.entries))
+	for _, entry := range s.entries {
+		nss = append(nss, entry.Clone())
+	}
+	s.mu.Unlock()
+
+	for _, ns := range nss {
+		s.replicateRegister(ns)
+	}
+	// Retry failed requests even if there is nothing to push
+	for _, p := range s.peers {
+		p.notify()
+	}
+}
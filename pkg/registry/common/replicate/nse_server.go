@@ -0,0 +1,270 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type replicateNSEServer struct {
+	ctx            context.Context
+	local          registry.NetworkServiceEndpointRegistryServer
+	peers          []*peer
+	isPeer         PeerAuthFunc
+	expiryDuration time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]*registry.NetworkServiceEndpoint
+	tombstones map[string]time.Time
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer replicating
+// Register/Unregister to the peer registries. Requests received from the peers are applied to the local server
+// instead of the rest of the chain, if they are newer than the known entry by expiration time. Local server should
+// contain all the elements keeping the registry state (expire, memory, etc.) and should not contain the elements
+// changing the entry (setid, etc.).
+//
+// Should be placed after the `serialize` and before the `expire` to see the final expiration time.
+func NewNetworkServiceEndpointRegistryServer(
+	ctx context.Context,
+	local registry.NetworkServiceEndpointRegistryServer,
+	options ...Option,
+) registry.NetworkServiceEndpointRegistryServer {
+	o := newOptions(options)
+
+	s := &replicateNSEServer{
+		ctx:            ctx,
+		local:          local,
+		peers:          newPeers(ctx, o),
+		isPeer:         o.isPeer,
+		expiryDuration: o.expiryDuration,
+		entries:        make(map[string]*registry.NetworkServiceEndpoint),
+		tombstones:     make(map[string]time.Time),
+	}
+	if len(s.peers) > 0 {
+		go s.syncLoop(o.syncInterval)
+	}
+
+	return s
+}
+
+func (s *replicateNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	replica, err := isReplica(ctx, s.isPeer)
+	if err != nil {
+		return nil, err
+	}
+	if replica {
+		return s.registerReplica(ctx, nse)
+	}
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.entries[resp.Name] = resp.Clone()
+	delete(s.tombstones, resp.Name)
+	s.mu.Unlock()
+
+	s.replicateRegister(resp.Clone())
+
+	return resp, nil
+}
+
+func (s *replicateNSEServer) registerReplica(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	s.mu.Lock()
+	if entry, ok := s.entries[nse.Name]; ok && !expirationTime(nse).After(expirationTime(entry)) {
+		s.mu.Unlock()
+		return entry.Clone(), nil
+	}
+	if tombstone, ok := s.tombstones[nse.Name]; ok && !expirationTime(nse).After(tombstone) {
+		s.mu.Unlock()
+		return nse, nil
+	}
+	s.mu.Unlock()
+
+	resp, err := s.local.Register(detach(ctx, s.ctx), nse)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.entries[resp.Name] = resp.Clone()
+	delete(s.tombstones, resp.Name)
+	s.mu.Unlock()
+
+	return resp, nil
+}
+
+func (s *replicateNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *replicateNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	replica, err := isReplica(ctx, s.isPeer)
+	if err != nil {
+		return nil, err
+	}
+	if replica {
+		return s.unregisterReplica(ctx, nse)
+	}
+
+	tombstone := nse.Clone()
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	tombstone.ExpirationTime = timestamppb.New(s.delete(tombstone.Name, expirationTime(tombstone)))
+	s.replicateUnregister(tombstone)
+
+	return resp, nil
+}
+
+func (s *replicateNSEServer) unregisterReplica(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[nse.Name]
+	s.mu.Unlock()
+
+	if ok {
+		if expirationTime(entry).After(expirationTime(nse)) {
+			// Entry has been registered again after the unregister
+			return new(empty.Empty), nil
+		}
+		if _, err := s.local.Unregister(detach(ctx, s.ctx), entry.Clone()); err != nil {
+			return nil, err
+		}
+	}
+
+	s.delete(nse.Name, expirationTime(nse))
+
+	return new(empty.Empty), nil
+}
+
+// delete - deletes the entry and stores the tombstone for it, returns the tombstone expiration time. Tombstone
+// expires no later than in expiryDuration, so unregister with a far future expiration time can't block the entry.
+func (s *replicateNSEServer) delete(name string, version time.Time) time.Time {
+	maxTombstone := clock.FromContext(s.ctx).Now().Add(s.expiryDuration)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tombstone := version
+	if entry, ok := s.entries[name]; ok && expirationTime(entry).After(tombstone) {
+		tombstone = expirationTime(entry)
+	}
+	if t, ok := s.tombstones[name]; ok && t.After(tombstone) {
+		tombstone = t
+	}
+	if tombstone.After(maxTombstone) {
+		tombstone = maxTombstone
+	}
+
+	delete(s.entries, name)
+	s.tombstones[name] = tombstone
+
+	return tombstone
+}
+
+func (s *replicateNSEServer) replicateRegister(nse *registry.NetworkServiceEndpoint) {
+	for _, p := range s.peers {
+		p.enqueue(nse.Name, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+			_, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Register(ctx, nse.Clone())
+			return err
+		})
+	}
+}
+
+func (s *replicateNSEServer) replicateUnregister(nse *registry.NetworkServiceEndpoint) {
+	for _, p := range s.peers {
+		p.enqueue(nse.Name, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+			_, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Unregister(ctx, nse.Clone())
+			return err
+		})
+	}
+}
+
+func (s *replicateNSEServer) syncLoop(syncInterval time.Duration) {
+	ticker := clock.FromContext(s.ctx).Ticker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C():
+			s.sync()
+		}
+	}
+}
+
+// sync - pushes all known entries and tombstones to the peers, drops the expired ones
+func (s *replicateNSEServer) sync() {
+	now := clock.FromContext(s.ctx).Now()
+
+	s.mu.Lock()
+	var nses, tombstones []*registry.NetworkServiceEndpoint
+	for name, entry := range s.entries {
+		if !expirationTime(entry).After(now) {
+			delete(s.entries, name)
+			continue
+		}
+		nses = append(nses, entry.Clone())
+	}
+	for name, t := range s.tombstones {
+		if !t.After(now) {
+			delete(s.tombstones, name)
+			continue
+		}
+		tombstones = append(tombstones, &registry.NetworkServiceEndpoint{
+			Name:           name,
+			ExpirationTime: timestamppb.New(t),
+		})
+	}
+	s.mu.Unlock()
+
+	for _, nse := range nses {
+		s.replicateRegister(nse)
+	}
+	for _, nse := range tombstones {
+		s.replicateUnregister(nse)
+	}
+	// Retry failed requests even if there is nothing to push
+	for _, p := range s.peers {
+		p.notify()
+	}
+}
+
+func expirationTime(nse *registry.NetworkServiceEndpoint) time.Time {
+	if nse.ExpirationTime == nil {
+		return time.Time{}
+	}
+	return nse.ExpirationTime.AsTime().Local()
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/replicate"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/serialize"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+const replicaKey = "nsm-registry-replica"
+
+func reserveURL(t *testing.T) *url.URL {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	u := grpcutils.AddressToURL(listener.Addr())
+	require.NoError(t, listener.Close())
+	return u
+}
+
+func startReplica(ctx context.Context, t *testing.T, serveURL *url.URL, peerURLs ...*url.URL) registry.NetworkServiceEndpointRegistryServer {
+	memoryServer := memory.NewNetworkServiceEndpointRegistryServer()
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		serialize.NewNetworkServiceEndpointRegistryServer(),
+		replicate.NewNetworkServiceEndpointRegistryServer(ctx,
+			chain.NewNetworkServiceEndpointRegistryServer(memoryServer),
+			replicate.WithPeers(peerURLs...),
+			replicate.WithDialOptions(grpc.WithInsecure()),
+			replicate.WithSyncInterval(100*time.Millisecond),
+			// Peers are connected with insecure connections, so there are no peer SPIFFE IDs
+			replicate.WithPeerAuthFunc(func(context.Context) bool { return true }),
+		),
+		memoryServer,
+	)
+
+	grpcServer := grpc.NewServer()
+	registry.RegisterNetworkServiceEndpointRegistryServer(grpcServer, server)
+
+	select {
+	case err := <-grpcutils.ListenAndServe(ctx, serveURL, grpcServer):
+		require.NoError(t, err)
+	default:
+	}
+
+	return server
+}
+
+func find(ctx context.Context, t *testing.T, server registry.NetworkServiceEndpointRegistryServer, name string) *registry.NetworkServiceEndpoint {
+	ch := make(chan *registry.NetworkServiceEndpoint, 10)
+	err := server.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: name,
+		},
+	}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+	require.NoError(t, err)
+	close(ch)
+
+	for nse := range ch {
+		if nse.Name == name {
+			return nse
+		}
+	}
+	return nil
+}
+
+func expiresAt(expirationTime time.Time) func(nse *registry.NetworkServiceEndpoint) bool {
+	return func(nse *registry.NetworkServiceEndpoint) bool {
+		return nse != nil && nse.ExpirationTime.AsTime().Equal(expirationTime)
+	}
+}
+
+func TestNSEServer_Replicate(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	urls := []*url.URL{reserveURL(t), reserveURL(t), reserveURL(t)}
+	var replicas []registry.NetworkServiceEndpointRegistryServer
+	for i := range urls {
+		var peerURLs []*url.URL
+		peerURLs = append(peerURLs, urls[:i]...)
+		peerURLs = append(peerURLs, urls[i+1:]...)
+		replicas = append(replicas, startReplica(ctx, t, urls[i], peerURLs...))
+	}
+
+	// 1. Register on the first replica
+	expirationTime := time.Now().Add(time.Hour).Round(time.Second)
+	_, err := replicas[0].Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-1",
+		ExpirationTime: timestamppb.New(expirationTime),
+	})
+	require.NoError(t, err)
+
+	for _, replica := range replicas {
+		replica := replica
+		require.Eventually(t, func() bool {
+			return expiresAt(expirationTime)(find(ctx, t, replica, "nse-1"))
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// 2. Refresh on the second replica
+	expirationTime = expirationTime.Add(time.Minute)
+	_, err = replicas[1].Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-1",
+		ExpirationTime: timestamppb.New(expirationTime),
+	})
+	require.NoError(t, err)
+
+	for _, replica := range replicas {
+		replica := replica
+		require.Eventually(t, func() bool {
+			return expiresAt(expirationTime)(find(ctx, t, replica, "nse-1"))
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// 3. Unregister on the third replica
+	_, err = replicas[2].Unregister(ctx, &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	})
+	require.NoError(t, err)
+
+	for _, replica := range replicas {
+		replica := replica
+		require.Eventually(t, func() bool {
+			return find(ctx, t, replica, "nse-1") == nil
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// 4. Tombstone sync doesn't resurrect the unregistered endpoint
+	require.Never(t, func() bool {
+		return find(ctx, t, replicas[0], "nse-1") != nil
+	}, 300*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestNSEServer_LastWriterWins(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := startReplica(ctx, t, reserveURL(t))
+	replicaCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(replicaKey, "true"))
+
+	now := time.Now().Round(time.Second)
+	nse := func(expirationTime time.Time) *registry.NetworkServiceEndpoint {
+		return &registry.NetworkServiceEndpoint{
+			Name:           "nse-1",
+			ExpirationTime: timestamppb.New(expirationTime),
+		}
+	}
+
+	_, err := server.Register(replicaCtx, nse(now.Add(2*time.Minute)))
+	require.NoError(t, err)
+
+	// Older registration is ignored
+	_, err = server.Register(replicaCtx, nse(now.Add(time.Minute)))
+	require.NoError(t, err)
+	require.True(t, expiresAt(now.Add(2*time.Minute))(find(ctx, t, server, "nse-1")))
+
+	// Unregister of the older registration is ignored
+	_, err = server.Unregister(replicaCtx, nse(now.Add(time.Minute)))
+	require.NoError(t, err)
+	require.NotNil(t, find(ctx, t, server, "nse-1"))
+
+	_, err = server.Unregister(replicaCtx, nse(now.Add(2*time.Minute)))
+	require.NoError(t, err)
+	require.Nil(t, find(ctx, t, server, "nse-1"))
+
+	// Registration older than the unregister is ignored
+	_, err = server.Register(replicaCtx, nse(now.Add(2*time.Minute)))
+	require.NoError(t, err)
+	require.Nil(t, find(ctx, t, server, "nse-1"))
+
+	_, err = server.Register(replicaCtx, nse(now.Add(3*time.Minute)))
+	require.NoError(t, err)
+	require.True(t, expiresAt(now.Add(3*time.Minute))(find(ctx, t, server, "nse-1")))
+}
+
+func TestNSEServer_ConvergeAfterPartition(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	urls := []*url.URL{reserveURL(t), reserveURL(t)}
+
+	first := startReplica(ctx, t, urls[0], urls[1])
+
+	expirationTime := time.Now().Add(time.Hour).Round(time.Second)
+	_, err := first.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-1",
+		ExpirationTime: timestamppb.New(expirationTime),
+	})
+	require.NoError(t, err)
+
+	// Second replica starts after the registration
+	second := startReplica(ctx, t, urls[1], urls[0])
+
+	require.Eventually(t, func() bool {
+		return expiresAt(expirationTime)(find(ctx, t, second, "nse-1"))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func withPeerID(ctx context.Context, t *testing.T, spiffeID string) context.Context {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			},
+		},
+	})
+}
+
+func TestNSEServer_PeerIDs(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	memoryServer := memory.NewNetworkServiceEndpointRegistryServer()
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		replicate.NewNetworkServiceEndpointRegistryServer(ctx,
+			chain.NewNetworkServiceEndpointRegistryServer(memoryServer),
+			replicate.WithPeerIDs("spiffe://test.com/registry"),
+		),
+		memoryServer,
+	)
+	replicaCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(replicaKey, "true"))
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:           "nse-1",
+		ExpirationTime: timestamppb.New(time.Now().Add(time.Minute)),
+	}
+
+	// Replica mark from the unauthenticated peer
+	_, err := server.Register(replicaCtx, nse.Clone())
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Replica mark from the unknown peer
+	_, err = server.Register(withPeerID(replicaCtx, t, "spiffe://test.com/nsc"), nse.Clone())
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, find(ctx, t, server, "nse-1"))
+
+	_, err = server.Register(withPeerID(replicaCtx, t, "spiffe://test.com/registry"), nse.Clone())
+	require.NoError(t, err)
+	require.NotNil(t, find(ctx, t, server, "nse-1"))
+}
+
+func TestNSEServer_TombstoneExpiration(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := startReplica(ctx, t, reserveURL(t))
+	replicaCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(replicaKey, "true"))
+
+	now := time.Now().Round(time.Second)
+	nse := func(expirationTime time.Time) *registry.NetworkServiceEndpoint {
+		return &registry.NetworkServiceEndpoint{
+			Name:           "nse-1",
+			ExpirationTime: timestamppb.New(expirationTime),
+		}
+	}
+
+	// Tombstone from the far future expires in the default 1m expiry duration
+	_, err := server.Unregister(replicaCtx, nse(now.Add(365*24*time.Hour)))
+	require.NoError(t, err)
+
+	_, err = server.Register(replicaCtx, nse(now.Add(2*time.Minute)))
+	require.NoError(t, err)
+	require.True(t, expiresAt(now.Add(2*time.Minute))(find(ctx, t, server, "nse-1")))
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// PeerAuthFunc - returns true if the incoming request has been sent by the trusted peer registry
+type PeerAuthFunc func(ctx context.Context) bool
+
+type replicateOptions struct {
+	peerURLs       []*url.URL
+	dialOptions    []grpc.DialOption
+	syncInterval   time.Duration
+	expiryDuration time.Duration
+	isPeer         PeerAuthFunc
+}
+
+// Option is an option for the replicate servers
+type Option func(o *replicateOptions)
+
+// WithPeers sets URLs of the peer registries
+func WithPeers(peerURLs ...*url.URL) Option {
+	return func(o *replicateOptions) {
+		o.peerURLs = peerURLs
+	}
+}
+
+// WithDialOptions sets gRPC Dial Options for the peer registries
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *replicateOptions) {
+		o.dialOptions = dialOptions
+	}
+}
+
+// WithSyncInterval sets interval of pushing all known entries to the peer registries, default is 10s
+func WithSyncInterval(syncInterval time.Duration) Option {
+	if syncInterval <= 0 {
+		panic("syncInterval should be positive")
+	}
+	return func(o *replicateOptions) {
+		o.syncInterval = syncInterval
+	}
+}
+
+// WithPeerIDs sets SPIFFE IDs of the peer registries. Requests marked as sent by the peer registry are applied only
+// if the peer TLS certificate has one of these SPIFFE IDs, by default no peers are trusted.
+func WithPeerIDs(peerIDs ...string) Option {
+	allowed := make(map[string]struct{}, len(peerIDs))
+	for _, id := range peerIDs {
+		allowed[id] = struct{}{}
+	}
+	return WithPeerAuthFunc(func(ctx context.Context) bool {
+		id, ok := peerID(ctx)
+		if !ok {
+			return false
+		}
+		_, ok = allowed[id]
+		return ok
+	})
+}
+
+// WithPeerAuthFunc sets custom authentication of the peer registries instead of the SPIFFE IDs set with WithPeerIDs
+func WithPeerAuthFunc(isPeer PeerAuthFunc) Option {
+	if isPeer == nil {
+		panic("isPeer cannot be nil")
+	}
+	return func(o *replicateOptions) {
+		o.isPeer = isPeer
+	}
+}
+
+// WithExpiryDuration sets max registration duration of the network service endpoints, tombstones of the unregistered
+// endpoints expire no later than in expiryDuration. Default is 1m.
+func WithExpiryDuration(expiryDuration time.Duration) Option {
+	if expiryDuration <= 0 {
+		panic("expiryDuration should be positive")
+	}
+	return func(o *replicateOptions) {
+		o.expiryDuration = expiryDuration
+	}
+}
+
+func newOptions(options []Option) *replicateOptions {
+	o := &replicateOptions{
+		syncInterval:   defaultSyncInterval,
+		expiryDuration: defaultExpiryDuration,
+		isPeer: func(context.Context) bool {
+			return false
+		},
+	}
+	for _, opt := range options {
+		opt(o)
+	}
+	return o
+}
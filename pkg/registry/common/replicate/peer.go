@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// replicateFunc - sends a single Register/Unregister to the peer
+type replicateFunc func(ctx context.Context, cc grpc.ClientConnInterface) error
+
+// peer - queue of the requests to replicate to a single peer registry. Only the last request for each name is kept, so
+// the queue is bounded by the number of entries even if the peer is down.
+type peer struct {
+	url         *url.URL
+	dialOptions []grpc.DialOption
+	cc          *grpc.ClientConn
+
+	mu      sync.Mutex
+	pending map[string]replicateFunc
+	signal  chan struct{}
+}
+
+func newPeers(ctx context.Context, o *replicateOptions) []*peer {
+	var peers []*peer
+	for _, u := range o.peerURLs {
+		p := &peer{
+			url:         u,
+			dialOptions: o.dialOptions,
+			pending:     make(map[string]replicateFunc),
+			signal:      make(chan struct{}, 1),
+		}
+		go p.run(ctx)
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// enqueue - replaces the pending request for the name and wakes up the peer
+func (p *peer) enqueue(name string, f replicateFunc) {
+	p.mu.Lock()
+	p.pending[name] = f
+	p.mu.Unlock()
+
+	p.notify()
+}
+
+// notify - wakes up the peer to send all pending requests
+func (p *peer) notify() {
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (p *peer) run(ctx context.Context) {
+	defer func() {
+		if p.cc != nil {
+			_ = p.cc.Close()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.signal:
+			p.flush(ctx)
+		}
+	}
+}
+
+func (p *peer) flush(ctx context.Context) {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = make(map[string]replicateFunc)
+	p.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	logger := log.FromContext(ctx).WithField("replicate", p.url.String())
+
+	if p.cc == nil {
+		dialCtx, cancel := clock.FromContext(ctx).WithTimeout(ctx, replicateTimeout)
+		cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(p.url), p.dialOptions...)
+		cancel()
+		if err != nil {
+			logger.Warnf("failed to dial peer registry: %s", err.Error())
+			p.requeue(pending)
+			return
+		}
+		p.cc = cc
+	}
+
+	failed := make(map[string]replicateFunc)
+	for name, f := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		if len(failed) > 0 {
+			// Peer is most probably down, don't wait for the timeout for each request
+			failed[name] = f
+			continue
+		}
+		replicateCtx, cancel := clock.FromContext(ctx).WithTimeout(ctx, replicateTimeout)
+		if err := f(withReplica(replicateCtx), p.cc); err != nil {
+			logger.Warnf("failed to replicate %s: %s", name, err.Error())
+			failed[name] = f
+		}
+		cancel()
+	}
+	p.requeue(failed)
+}
+
+// requeue - returns failed requests to the queue unless they have been replaced with the newer ones, they are retried
+// on the next wake up
+func (p *peer) requeue(failed map[string]replicateFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name, f := range failed {
+		if _, ok := p.pending[name]; !ok {
+			p.pending[name] = f
+		}
+	}
+}
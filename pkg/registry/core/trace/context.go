@@ -23,6 +23,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/jsonlogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/spanlogger"
 )
@@ -39,7 +40,11 @@ func withLog(parent context.Context, operation string) (c context.Context, f fun
 	if grpcTraceState := grpcutils.TraceFromContext(parent); (grpcTraceState == grpcutils.TraceOn) ||
 		(grpcTraceState == grpcutils.TraceUndefined && log.IsTracingEnabled()) {
 		ctx, sLogger, span, sFinish := spanlogger.FromContext(parent, operation)
-		ctx, lLogger, lFinish := logruslogger.FromSpan(ctx, span, operation)
+		fromSpan := logruslogger.FromSpan
+		if log.IsJSONFormatEnabled() {
+			fromSpan = jsonlogger.FromSpan
+		}
+		ctx, lLogger, lFinish := fromSpan(ctx, span, operation)
 		return log.WithLog(ctx, sLogger, lLogger), func() {
 			sFinish()
 			lFinish()
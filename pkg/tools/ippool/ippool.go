@@ -20,8 +20,12 @@ package ippool
 import (
 	"errors"
 	"math"
+	"math/rand"
 	"net"
 	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type color bool
@@ -37,6 +41,14 @@ type IPPool struct {
 	lock     sync.Mutex
 	size     uint64
 	ipLength int
+
+	strategy AllocationStrategy
+	cursor   *ipAddress
+	random   *rand.Rand
+
+	quarantinePeriod time.Duration
+	quarantined      []*quarantinedRange
+	clock            clock.Clock
 }
 
 // treeNode is a single element within the IP pool tree
@@ -91,6 +103,9 @@ func (tree *IPPool) clone() *IPPool {
 		root:     nil,
 		size:     tree.size,
 		ipLength: tree.ipLength,
+		strategy: tree.strategy,
+		cursor:   tree.cursor,
+		random:   tree.random,
 	}
 
 	if tree.root == nil {
@@ -168,7 +183,9 @@ func (tree *IPPool) Exclude(ipNet *net.IPNet) {
 	tree.lock.Lock()
 	defer tree.lock.Unlock()
 
-	tree.deleteRange(ipRangeFromIPNet(ipNet))
+	ipR := ipRangeFromIPNet(ipNet)
+	tree.deleteRange(ipR)
+	tree.excludeQuarantined(ipR)
 }
 
 // ExcludeString - exclude network from pool by string value
@@ -186,6 +203,8 @@ func (tree *IPPool) Pull() (net.IP, error) {
 	tree.lock.Lock()
 	defer tree.lock.Unlock()
 
+	tree.releaseQuarantined()
+
 	ip := tree.pull()
 	if ip == nil {
 		return nil, errors.New("IPPool is empty")
@@ -198,6 +217,8 @@ func (tree *IPPool) PullP2PAddrs(exclude ...*IPPool) (srcNet, dstNet *net.IPNet,
 	tree.lock.Lock()
 	defer tree.lock.Unlock()
 
+	tree.releaseQuarantined()
+
 	clone := tree.clone()
 
 	for _, pool := range exclude {
@@ -222,6 +243,7 @@ func (tree *IPPool) PullP2PAddrs(exclude ...*IPPool) (srcNet, dstNet *net.IPNet,
 		start: dstIP.Clone(),
 		end:   dstIP.Clone(),
 	})
+	tree.cursor = clone.cursor
 
 	srcNet = &net.IPNet{
 		IP:   ipFromIPAddress(srcIP, tree.ipLength),
@@ -333,21 +355,6 @@ func (tree *IPPool) add(ip *ipAddress) {
 	tree.addRange(ipR)
 }
 
-func (tree *IPPool) pull() *ipAddress {
-	node := tree.left()
-	if node == nil {
-		return nil
-	}
-
-	ip := node.Value.start
-	if node.Value.start.Equal(node.Value.end) {
-		tree.removeNode(node)
-		return ip
-	}
-	node.Value.start = node.Value.start.Next()
-	return ip
-}
-
 func (tree *IPPool) deleteRange(ipR *ipRange) {
 	node := tree.root
 	for node != nil {
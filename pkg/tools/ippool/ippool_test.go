@@ -22,11 +22,13 @@ import (
 	"runtime"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
 	"github.com/networkservicemesh/sdk/pkg/tools/prefixpool"
 )
 
@@ -358,6 +360,120 @@ func TestIPPoolTool_IPv6GetPrefixes(t *testing.T) {
 	require.Equal(t, prefixes[0], "::/0")
 }
 
+func TestIPPoolTool_RoundRobinStrategy(t *testing.T) {
+	ipPool := NewWithNetString("192.0.0.0/30")
+	require.NotNil(t, ipPool)
+	ipPool.SetAllocationStrategy(RoundRobinStrategy)
+
+	ip, err := ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.0", ip.String())
+
+	ipPool.AddString("192.0.0.0")
+
+	ip, err = ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.1", ip.String())
+
+	srcIPNet, dstIPNet, err := ipPool.PullP2PAddrs()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.2/32", srcIPNet.String())
+	require.Equal(t, "192.0.0.3/32", dstIPNet.String())
+
+	ip, err = ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.0", ip.String())
+
+	_, err = ipPool.Pull()
+	require.Error(t, err)
+}
+
+func TestIPPoolTool_RandomStrategy(t *testing.T) {
+	ipPool := NewWithNetString("192.0.0.0/24")
+	require.NotNil(t, ipPool)
+	ipPool.SetAllocationStrategy(RandomStrategy)
+
+	pulled := make(map[string]bool)
+	for i := 0; i < 256; i++ {
+		ip, err := ipPool.Pull()
+		require.NoError(t, err)
+		require.False(t, pulled[ip.String()])
+		require.Equal(t, "192.0.0.0", ip.Mask(net.CIDRMask(24, 32)).String())
+		pulled[ip.String()] = true
+	}
+
+	_, err := ipPool.Pull()
+	require.Error(t, err)
+}
+
+func TestIPPoolTool_IPv6RandomStrategy(t *testing.T) {
+	ipPool := NewWithNetString("fe80::/64")
+	require.NotNil(t, ipPool)
+	ipPool.SetAllocationStrategy(RandomStrategy)
+
+	_, ipNet, err := net.ParseCIDR("fe80::/64")
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		srcIPNet, dstIPNet, err := ipPool.PullP2PAddrs()
+		require.NoError(t, err)
+		require.True(t, ipNet.Contains(srcIPNet.IP))
+		require.True(t, ipNet.Contains(dstIPNet.IP))
+		require.NotEqual(t, srcIPNet.String(), dstIPNet.String())
+		require.False(t, ipPool.Contains(srcIPNet.IP))
+		require.False(t, ipPool.Contains(dstIPNet.IP))
+	}
+}
+
+func TestIPPoolTool_Quarantine(t *testing.T) {
+	clockMock := clockmock.NewMock()
+
+	ipPool := NewWithNetString("192.0.0.0/31")
+	require.NotNil(t, ipPool)
+	ipPool.SetQuarantine(time.Minute, clockMock)
+
+	ip, err := ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.0", ip.String())
+
+	ipPool.ReleaseNetString("192.0.0.0/32")
+
+	ip, err = ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.1", ip.String())
+
+	_, err = ipPool.Pull()
+	require.Error(t, err)
+
+	clockMock.Add(time.Minute)
+
+	ip, err = ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.0", ip.String())
+}
+
+func TestIPPoolTool_QuarantineExclude(t *testing.T) {
+	clockMock := clockmock.NewMock()
+
+	ipPool := NewWithNetString("192.0.0.0/31")
+	require.NotNil(t, ipPool)
+	ipPool.SetQuarantine(time.Minute, clockMock)
+
+	_, err := ipPool.Pull()
+	require.NoError(t, err)
+	ipPool.ReleaseNetString("192.0.0.0/32")
+	ipPool.ExcludeString("192.0.0.0/32")
+
+	clockMock.Add(time.Minute)
+
+	ip, err := ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, "192.0.0.1", ip.String())
+
+	_, err = ipPool.Pull()
+	require.Error(t, err)
+}
+
 func BenchmarkIPPool(b *testing.B) {
 	b.Run("IPPool", func(b *testing.B) {
 		benchmarkIPPool(b, b.N, runtime.GOMAXPROCS(0), 1000)
@@ -0,0 +1,241 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ippool
+
+import (
+	"math"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+// AllocationStrategy - defines which of the free addresses is pulled from the pool
+type AllocationStrategy int
+
+const (
+	// LowestStrategy - pulls the lowest free address, it is the default strategy
+	LowestStrategy AllocationStrategy = iota
+	// RandomStrategy - pulls a random free address
+	RandomStrategy
+	// RoundRobinStrategy - pulls the lowest free address following the previously pulled one, wraps around at the end
+	// of the pool
+	RoundRobinStrategy
+)
+
+type quarantinedRange struct {
+	*ipRange
+	until time.Time
+}
+
+// SetAllocationStrategy - sets strategy used by Pull, PullP2PAddrs
+func (tree *IPPool) SetAllocationStrategy(strategy AllocationStrategy) {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+
+	tree.strategy = strategy
+	if strategy == RandomStrategy && tree.random == nil {
+		// #nosec
+		tree.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+}
+
+// SetQuarantine - sets period for which the addresses returned with Release, ReleaseNetString are not pulled again.
+// clk is used to measure the period.
+func (tree *IPPool) SetQuarantine(period time.Duration, clk clock.Clock) {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+
+	tree.quarantinePeriod = period
+	tree.clock = clk
+}
+
+// Release - returns ip addresses from network to the pool after the quarantine period
+func (tree *IPPool) Release(ipNet *net.IPNet) {
+	if ipNet == nil || tree.ipLength != len(ipNet.IP) {
+		return
+	}
+
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+
+	if tree.quarantinePeriod <= 0 {
+		tree.addRange(ipRangeFromIPNet(ipNet))
+		return
+	}
+	tree.quarantined = append(tree.quarantined, &quarantinedRange{
+		ipRange: ipRangeFromIPNet(ipNet),
+		until:   tree.clock.Now().Add(tree.quarantinePeriod),
+	})
+}
+
+// ReleaseNetString - returns ip addresses from network to the pool after the quarantine period by string value
+func (tree *IPPool) ReleaseNetString(ipNetString string) {
+	_, ipNet, err := net.ParseCIDR(ipNetString)
+	if err != nil {
+		return
+	}
+
+	tree.Release(ipNet)
+}
+
+// releaseQuarantined - returns ip addresses with the passed quarantine period to the pool
+func (tree *IPPool) releaseQuarantined() {
+	if len(tree.quarantined) == 0 {
+		return
+	}
+
+	now := tree.clock.Now()
+	var quarantined []*quarantinedRange
+	for _, q := range tree.quarantined {
+		if now.Before(q.until) {
+			quarantined = append(quarantined, q)
+			continue
+		}
+		tree.addRange(q.ipRange)
+	}
+	tree.quarantined = quarantined
+}
+
+// excludeQuarantined - removes ip range from the quarantined ones, so it is not returned to the pool
+func (tree *IPPool) excludeQuarantined(ipR *ipRange) {
+	if len(tree.quarantined) == 0 {
+		return
+	}
+
+	var quarantined []*quarantinedRange
+	for _, q := range tree.quarantined {
+		lRange, rRange := q.Sub(ipR)
+		if lRange != nil {
+			quarantined = append(quarantined, &quarantinedRange{ipRange: lRange, until: q.until})
+		}
+		if rRange != nil {
+			quarantined = append(quarantined, &quarantinedRange{ipRange: rRange, until: q.until})
+		}
+	}
+	tree.quarantined = quarantined
+}
+
+func (tree *IPPool) pull() *ipAddress {
+	switch tree.strategy {
+	case RandomStrategy:
+		return tree.pullRandom()
+	case RoundRobinStrategy:
+		return tree.pullRoundRobin()
+	default:
+		return tree.pullLowest()
+	}
+}
+
+func (tree *IPPool) pullLowest() *ipAddress {
+	node := tree.left()
+	if node == nil {
+		return nil
+	}
+
+	ip := node.Value.start
+	if node.Value.start.Equal(node.Value.end) {
+		tree.removeNode(node)
+		return ip
+	}
+	node.Value.start = node.Value.start.Next()
+	return ip
+}
+
+func (tree *IPPool) pullRoundRobin() *ipAddress {
+	var ip *ipAddress
+	if tree.cursor != nil && !tree.cursor.IsLast() {
+		next := tree.cursor.Next()
+		if node := tree.ceiling(next); node != nil {
+			if node.Value.Compare(next) == 0 {
+				ip = next
+			} else {
+				ip = node.Value.start.Clone()
+			}
+			tree.deleteRange(&ipRange{start: ip, end: ip})
+		}
+	}
+	if ip == nil {
+		if ip = tree.pullLowest(); ip == nil {
+			return nil
+		}
+	}
+
+	tree.cursor = ip.Clone()
+	return ip
+}
+
+func (tree *IPPool) pullRandom() *ipAddress {
+	if tree.root == nil {
+		return nil
+	}
+
+	it := iterator{
+		node: tree.root,
+	}
+	for it.node.Left != nil {
+		it.node = it.node.Left
+	}
+
+	var nodes []*treeNode
+	for node := it.Next(); node != nil; node = it.Next() {
+		nodes = append(nodes, node)
+	}
+	ipR := nodes[tree.random.Intn(len(nodes))].Value
+
+	ip := &ipAddress{
+		high: randomBetween(tree.random, ipR.start.high, ipR.end.high),
+	}
+	lowStart, lowEnd := uint64(0), uint64(math.MaxUint64)
+	if ip.high == ipR.start.high {
+		lowStart = ipR.start.low
+	}
+	if ip.high == ipR.end.high {
+		lowEnd = ipR.end.low
+	}
+	ip.low = randomBetween(tree.random, lowStart, lowEnd)
+
+	tree.deleteRange(&ipRange{start: ip, end: ip})
+	return ip
+}
+
+// ceiling - returns node containing ip or the lowest node following ip
+func (tree *IPPool) ceiling(ip *ipAddress) *treeNode {
+	var result *treeNode
+	node := tree.root
+	for node != nil {
+		compare := node.Value.Compare(ip)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			result = node
+			node = node.Left
+		case compare > 0:
+			node = node.Right
+		}
+	}
+	return result
+}
+
+func randomBetween(random *rand.Rand, min, max uint64) uint64 {
+	if max-min == math.MaxUint64 {
+		return random.Uint64()
+	}
+	return min + random.Uint64()%(max-min+1)
+}
@@ -17,91 +17,28 @@
 // limitations under the License.
 
 // Package jaeger provides a set of utilities for assisting with using jaeger
+//
+// Deprecated: jaeger is replaced with OpenTelemetry, use opentelemetry package instead. This package forwards to
+// opentelemetry and will be removed in the next release.
 package jaeger
 
 import (
 	"context"
-	"fmt"
 	"io"
-	"os"
-	"strconv"
-
-	"github.com/networkservicemesh/sdk/pkg/tools/log"
-
-	"github.com/opentracing/opentracing-go"
-	"github.com/uber/jaeger-client-go"
-	"github.com/uber/jaeger-client-go/config"
-)
 
-const (
-	opentracingEnv     = "TRACER_ENABLED"
-	opentracingDefault = true
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 )
 
 // IsOpentracingEnabled returns true if opentracing enabled
+//
+// Deprecated: use opentelemetry.IsEnabled instead.
 func IsOpentracingEnabled() bool {
-	val, err := readEnvBool(opentracingEnv, opentracingDefault)
-	if err == nil {
-		return val
-	}
-	return opentracingDefault
-}
-
-func readEnvBool(env string, value bool) (bool, error) {
-	str := os.Getenv(env)
-	if str == "" {
-		return value, nil
-	}
-
-	return strconv.ParseBool(str)
+	return opentelemetry.IsEnabled()
 }
 
-type emptyCloser struct {
-}
-
-func (*emptyCloser) Close() error {
-	// Ignore
-	return nil
-}
-
-// InitJaeger -  returns an instance of Jaeger Tracer that samples 100% of traces and logs all spans to stdout.
+// InitJaeger - initializes OpenTelemetry tracing exporting spans to the OTLP collector, see opentelemetry.Init.
+//
+// Deprecated: use opentelemetry.Init instead.
 func InitJaeger(ctx context.Context, service string) io.Closer {
-	if !IsOpentracingEnabled() {
-		return &emptyCloser{}
-	}
-	if opentracing.IsGlobalTracerRegistered() {
-		log.FromContext(ctx).Warnf("global opentracer is already initialized")
-	}
-	cfg, err := config.FromEnv()
-	if err != nil {
-		panic(fmt.Sprintf("ERROR: cannot create Jaeger configuration: %v\n", err))
-	}
-
-	if cfg.ServiceName == "" {
-		var hostname string
-		hostname, err = os.Hostname()
-		if err == nil {
-			cfg.ServiceName = fmt.Sprintf("%s@%s", service, hostname)
-		} else {
-			cfg.ServiceName = service
-		}
-	}
-	if cfg.Sampler.Type == "" {
-		cfg.Sampler.Type = "const"
-	}
-	if cfg.Sampler.Param == 0 {
-		cfg.Sampler.Param = 1
-	}
-	if !cfg.Reporter.LogSpans {
-		cfg.Reporter.LogSpans = true
-	}
-
-	log.FromContext(ctx).Infof("Creating logger from config: %v", cfg)
-	tracer, closer, err := cfg.NewTracer(config.Logger(jaeger.StdLogger))
-	if err != nil {
-		log.FromContext(ctx).Errorf("ERROR: cannot init Jaeger: %v\n", err)
-		return &emptyCloser{}
-	}
-	opentracing.SetGlobalTracer(tracer)
-	return closer
+	return opentelemetry.Init(ctx, service)
 }
@@ -0,0 +1,157 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonlogger provides structured JSON logger consistent with Logger interface
+package jsonlogger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// ElementKey - field key for the chain element name
+	ElementKey = "element"
+	// TraceIDKey - field key for the trace ID
+	TraceIDKey = "trace_id"
+	// SpanIDKey - field key for the span ID
+	SpanIDKey = "span_id"
+)
+
+var logger = &logrus.Logger{
+	Out:       os.Stderr,
+	Formatter: &logrus.JSONFormatter{},
+	Hooks:     make(logrus.LevelHooks),
+	Level:     logrus.InfoLevel,
+}
+
+// SetOutput - sets output for all JSON loggers, default is os.Stderr
+func SetOutput(out io.Writer) {
+	logger.SetOutput(out)
+}
+
+// SetLevel - sets level for all JSON loggers, default is logrus.InfoLevel
+func SetLevel(level logrus.Level) {
+	logger.SetLevel(level)
+}
+
+type jsonLogger struct {
+	entry *logrus.Entry
+}
+
+// New - creates a JSON logger with the fields from context
+func New(ctx context.Context) log.Logger {
+	return &jsonLogger{
+		entry: logger.WithFields(log.Fields(ctx)),
+	}
+}
+
+// FromSpan - creates a new JSON logger from context, operation and span and returns context with it, logger, and
+// a function to defer. Each line carries operation as element name, trace and span IDs as separate fields.
+func FromSpan(ctx context.Context, span trace.Span, operation string) (context.Context, log.Logger, func()) {
+	entry := logger.WithFields(log.Fields(ctx)).WithField(ElementKey, operation)
+	if span != nil {
+		if spanContext := span.SpanContext(); spanContext.IsValid() {
+			entry = entry.WithFields(logrus.Fields{
+				TraceIDKey: spanContext.TraceID().String(),
+				SpanIDKey:  spanContext.SpanID().String(),
+			})
+		}
+	}
+	return ctx, &jsonLogger{entry: entry}, func() {}
+}
+
+func (s *jsonLogger) Info(v ...interface{}) {
+	s.entry.Info(v...)
+}
+
+func (s *jsonLogger) Infof(format string, v ...interface{}) {
+	s.entry.Infof(format, v...)
+}
+
+func (s *jsonLogger) Warn(v ...interface{}) {
+	s.entry.Warn(v...)
+}
+
+func (s *jsonLogger) Warnf(format string, v ...interface{}) {
+	s.entry.Warnf(format, v...)
+}
+
+func (s *jsonLogger) Error(v ...interface{}) {
+	s.entry.Error(v...)
+}
+
+func (s *jsonLogger) Errorf(format string, v ...interface{}) {
+	s.entry.Errorf(format, v...)
+}
+
+func (s *jsonLogger) Fatal(v ...interface{}) {
+	s.entry.Fatal(v...)
+}
+
+func (s *jsonLogger) Fatalf(format string, v ...interface{}) {
+	s.entry.Fatalf(format, v...)
+}
+
+func (s *jsonLogger) Debug(v ...interface{}) {
+	s.entry.Debug(v...)
+}
+
+func (s *jsonLogger) Debugf(format string, v ...interface{}) {
+	s.entry.Debugf(format, v...)
+}
+
+func (s *jsonLogger) Trace(v ...interface{}) {
+	s.entry.Trace(v...)
+}
+
+func (s *jsonLogger) Tracef(format string, v ...interface{}) {
+	s.entry.Tracef(format, v...)
+}
+
+// Object - logs v as a value of the k field, protobuf messages are marshaled with protojson
+func (s *jsonLogger) Object(k, v interface{}) {
+	key := fmt.Sprint(k)
+	s.entry.WithField(key, toJSON(v)).Info(key)
+}
+
+func (s *jsonLogger) WithField(key, value interface{}) log.Logger {
+	return &jsonLogger{
+		entry: s.entry.WithField(fmt.Sprint(key), value),
+	}
+}
+
+func toJSON(v interface{}) interface{} {
+	if m, ok := v.(proto.Message); ok {
+		if bytes, err := protojson.Marshal(m); err == nil {
+			return json.RawMessage(bytes)
+		}
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return v
+}
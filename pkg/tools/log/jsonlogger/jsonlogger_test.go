@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonlogger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/jsonlogger"
+)
+
+func readLines(t *testing.T, buff *bytes.Buffer) []map[string]interface{} {
+	var rv []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buff.String()), "\n") {
+		entry := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		rv = append(rv, entry)
+	}
+	return rv
+}
+
+func TestFromSpan(t *testing.T) {
+	var buff bytes.Buffer
+	jsonlogger.SetOutput(&buff)
+	jsonlogger.SetLevel(logrus.DebugLevel)
+
+	ctx := log.WithFields(context.Background(), map[string]interface{}{"name": "nsmgr"})
+	ctx = log.WithFields(ctx, map[string]interface{}{"connection_id": "conn-1"})
+
+	tracerProvider := sdktrace.NewTracerProvider()
+	defer func() { _ = tracerProvider.Shutdown(context.Background()) }()
+	_, span := tracerProvider.Tracer("test").Start(ctx, "operation")
+	defer span.End()
+
+	_, logger, finish := jsonlogger.FromSpan(ctx, span, "operation")
+	defer finish()
+
+	logger.WithField("key", "value").Debugf("message %d", 1)
+	logger.Errorf("failed")
+
+	lines := readLines(t, &buff)
+	require.Len(t, lines, 2)
+
+	require.Equal(t, "debug", lines[0]["level"])
+	require.Equal(t, "message 1", lines[0]["msg"])
+	require.Equal(t, "value", lines[0]["key"])
+	require.Equal(t, "error", lines[1]["level"])
+	require.NotContains(t, lines[1], "key")
+	for _, line := range lines {
+		require.Equal(t, "nsmgr", line["name"])
+		require.Equal(t, "conn-1", line["connection_id"])
+		require.Equal(t, "operation", line[jsonlogger.ElementKey])
+		require.Equal(t, span.SpanContext().TraceID().String(), line[jsonlogger.TraceIDKey])
+		require.Equal(t, span.SpanContext().SpanID().String(), line[jsonlogger.SpanIDKey])
+	}
+}
+
+func TestObject(t *testing.T) {
+	var buff bytes.Buffer
+	jsonlogger.SetOutput(&buff)
+	jsonlogger.SetLevel(logrus.InfoLevel)
+
+	logger := jsonlogger.New(context.Background())
+	logger.Object("request", &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "conn-1",
+			NetworkService: "ns",
+		},
+	})
+	logger.Object("labels", map[string]string{"app": "nse"})
+
+	lines := readLines(t, &buff)
+	require.Len(t, lines, 2)
+
+	require.Equal(t, map[string]interface{}{
+		"connection": map[string]interface{}{
+			"id":             "conn-1",
+			"networkService": "ns",
+		},
+	}, lines[0]["request"])
+	require.Equal(t, map[string]interface{}{"app": "nse"}, lines[1]["labels"])
+}
@@ -29,7 +29,8 @@ const (
 )
 
 var (
-	isTracingEnabled = false
+	isTracingEnabled    = false
+	isJSONFormatEnabled = false
 )
 
 // Logger - unified interface for logging
@@ -84,9 +85,21 @@ func Fields(ctx context.Context) map[string]interface{} {
 	return nil
 }
 
-// WithFields - adds fields to context
+// WithFields - adds fields to context, fields already stored in context are kept unless overridden
 func WithFields(ctx context.Context, fields map[string]interface{}) context.Context {
-	return context.WithValue(ctx, logFieldsKey, fields)
+	parentFields := Fields(ctx)
+	if len(parentFields) == 0 {
+		return context.WithValue(ctx, logFieldsKey, fields)
+	}
+
+	rv := make(map[string]interface{}, len(parentFields)+len(fields))
+	for k, v := range parentFields {
+		rv[k] = v
+	}
+	for k, v := range fields {
+		rv[k] = v
+	}
+	return context.WithValue(ctx, logFieldsKey, rv)
 }
 
 // IsTracingEnabled - checks if it is allowed to use traces
@@ -98,3 +111,13 @@ func IsTracingEnabled() bool {
 func EnableTracing(enable bool) {
 	isTracingEnabled = enable
 }
+
+// IsJSONFormatEnabled - checks if traces should be logged in structured JSON format
+func IsJSONFormatEnabled() bool {
+	return isJSONFormatEnabled
+}
+
+// EnableJSONFormat - enable/disable structured JSON format for traces
+func EnableJSONFormat(enable bool) {
+	isJSONFormatEnabled = enable
+}
@@ -26,8 +26,8 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
@@ -93,15 +93,17 @@ func fromContext(ctx context.Context) *traceCtxInfo {
 
 type logrusLogger struct {
 	entry     *logrus.Entry
-	span      opentracing.Span
+	span      trace.Span
 	info      *traceCtxInfo
 	operation string
 }
 
 func (s *logrusLogger) getSpan() string {
-	spanStr := fmt.Sprintf("%v", s.span)
-	if len(spanStr) > 0 && spanStr != "{}" && s.span != nil {
-		return fmt.Sprintf(" span=%v", spanStr)
+	if s.span == nil {
+		return ""
+	}
+	if spanContext := s.span.SpanContext(); spanContext.IsValid() {
+		return fmt.Sprintf(" span=%v:%v", spanContext.TraceID(), spanContext.SpanID())
 	}
 	return ""
 }
@@ -194,7 +196,7 @@ func New(ctx context.Context) log.Logger {
 
 // FromSpan - creates a new logruslogger from context, operation and span
 // and returns context with it, logger, and a function to defer
-func FromSpan(ctx context.Context, span opentracing.Span, operation string) (context.Context, log.Logger, func()) {
+func FromSpan(ctx context.Context, span trace.Span, operation string) (context.Context, log.Logger, func()) {
 	entry := logrus.WithFields(log.Fields(ctx))
 
 	var info *traceCtxInfo
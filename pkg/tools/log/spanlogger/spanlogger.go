@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package spanlogger provides a set of utilities to assist in working with opentracing spans
+// Package spanlogger provides a set of utilities to assist in working with OpenTelemetry spans
 package spanlogger
 
 import (
@@ -24,11 +24,12 @@ import (
 	"runtime/debug"
 	"strings"
 
-	"github.com/opentracing/opentracing-go"
-	opentracinglog "github.com/opentracing/opentracing-go/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
-	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 )
 
 const (
@@ -36,9 +37,9 @@ const (
 	dotCount        int = 3
 )
 
-// spanlogger - provides a way to log via opentracing spans
+// spanlogger - provides a way to log via OpenTelemetry span events
 type spanLogger struct {
-	span    opentracing.Span
+	span    trace.Span
 	entries map[interface{}]interface{}
 }
 
@@ -101,10 +102,8 @@ func (s *spanLogger) Object(k, v interface{}) {
 				msg = fmt.Sprint(v)
 			}
 
-			s.span.LogFields(opentracinglog.Object(k.(string), limitString(msg)))
-			for k, v := range s.entries {
-				s.span.LogKV(k, v)
-			}
+			attrs := append(s.attributes(), attribute.String(k.(string), limitString(msg)))
+			s.span.AddEvent(k.(string), trace.WithAttributes(attrs...))
 		}
 	}
 }
@@ -129,19 +128,29 @@ func (s *spanLogger) log(level string, v ...interface{}) {
 func (s *spanLogger) logf(level, format string, v ...interface{}) {
 	if s.span != nil {
 		if v != nil {
-			s.span.LogFields(opentracinglog.String("event", level), opentracinglog.String("message", fmt.Sprintf(format, v...)))
-			for k, v := range s.entries {
-				s.span.LogKV(k, v)
+			msg := fmt.Sprintf(format, v...)
+			attrs := append(s.attributes(), attribute.String("message", msg))
+			s.span.AddEvent(level, trace.WithAttributes(attrs...))
+			if level == "error" {
+				s.span.SetStatus(codes.Error, limitString(msg))
 			}
 		}
 	}
 }
 
-// FromContext - creates a new spanLogger from context and operation
-func FromContext(ctx context.Context, operation string) (context.Context, log.Logger, opentracing.Span, func()) {
-	var span opentracing.Span
-	if jaeger.IsOpentracingEnabled() {
-		span, ctx = opentracing.StartSpanFromContext(ctx, operation)
+func (s *spanLogger) attributes() []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(s.entries)+1)
+	for k, v := range s.entries {
+		attrs = append(attrs, attribute.String(fmt.Sprint(k), fmt.Sprint(v)))
+	}
+	return attrs
+}
+
+// FromContext - creates a new spanLogger from context and operation, span is started as a child of the span from ctx
+func FromContext(ctx context.Context, operation string) (context.Context, log.Logger, trace.Span, func()) {
+	var span trace.Span
+	if opentelemetry.IsEnabled() {
+		ctx, span = opentelemetry.Tracer().Start(ctx, operation)
 	}
 	newLog := &spanLogger{
 		span:    span,
@@ -153,7 +162,7 @@ func FromContext(ctx context.Context, operation string) (context.Context, log.Lo
 // finish - closes spanLogger
 func (s *spanLogger) finish() {
 	if s.span != nil {
-		s.span.Finish()
+		s.span.End()
 		s.span = nil
 	}
 }
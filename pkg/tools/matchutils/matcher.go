@@ -0,0 +1,175 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchutils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// NetworkServiceMatcher - network service query parsed once to be matched against any number of network services
+type NetworkServiceMatcher struct {
+	query *registry.NetworkService
+	name  func(string) bool
+}
+
+// NewNetworkServiceMatcher returns a matcher for the query or an error if the query has invalid name regular
+// expression
+func NewNetworkServiceMatcher(query *registry.NetworkService) (*NetworkServiceMatcher, error) {
+	name, err := compileName(query.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkServiceMatcher{
+		query: query,
+		name:  name,
+	}, nil
+}
+
+// Match returns true if the network service matches the query
+func (m *NetworkServiceMatcher) Match(ns *registry.NetworkService) bool {
+	return m.name(ns.GetName()) &&
+		(m.query.GetPayload() == "" || m.query.GetPayload() == ns.GetPayload()) &&
+		(m.query.GetMatches() == nil || cmp.Equal(m.query.GetMatches(), ns.GetMatches(), cmp.Comparer(proto.Equal)))
+}
+
+// NetworkServiceEndpointMatcher - network service endpoint query parsed once to be matched against any number of
+// network service endpoints
+type NetworkServiceEndpointMatcher struct {
+	query  *registry.NetworkServiceEndpoint
+	name   func(string) bool
+	labels map[string]*labelsMatcher
+}
+
+// NewNetworkServiceEndpointMatcher returns a matcher for the query or an error if the query has invalid name regular
+// expression or invalid selector expressions
+func NewNetworkServiceEndpointMatcher(query *registry.NetworkServiceEndpoint) (*NetworkServiceEndpointMatcher, error) {
+	name, err := compileName(query.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	var labels map[string]*labelsMatcher
+	if query.GetNetworkServiceLabels() != nil {
+		labels = make(map[string]*labelsMatcher, len(query.GetNetworkServiceLabels()))
+		for service, serviceLabels := range query.GetNetworkServiceLabels() {
+			if labels[service], err = compileLabels(serviceLabels.GetLabels()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &NetworkServiceEndpointMatcher{
+		query:  query,
+		name:   name,
+		labels: labels,
+	}, nil
+}
+
+// Match returns true if the network service endpoint matches the query
+func (m *NetworkServiceEndpointMatcher) Match(nse *registry.NetworkServiceEndpoint) bool {
+	return m.name(nse.GetName()) &&
+		(m.labels == nil || m.matchLabels(nse.GetNetworkServiceLabels())) &&
+		(m.query.GetExpirationTime() == nil || m.query.GetExpirationTime().GetSeconds() == nse.GetExpirationTime().GetSeconds()) &&
+		(m.query.GetNetworkServiceNames() == nil || contains(nse.GetNetworkServiceNames(), m.query.GetNetworkServiceNames())) &&
+		(m.query.GetUrl() == "" || strings.Contains(nse.GetUrl(), m.query.GetUrl()))
+}
+
+func (m *NetworkServiceEndpointMatcher) matchLabels(where map[string]*registry.NetworkServiceLabels) bool {
+	for service, labels := range m.labels {
+		if service == AnyNetworkService {
+			if !labels.matchAny(where) {
+				return false
+			}
+			continue
+		}
+		serviceLabels, ok := where[service]
+		if !ok || !labels.match(serviceLabels.GetLabels()) {
+			return false
+		}
+	}
+	return true
+}
+
+// labelsMatcher - query labels with the parsed SelectorKey selector expression
+type labelsMatcher struct {
+	labels   map[string]string
+	selector *Selector
+}
+
+func compileLabels(query map[string]string) (*labelsMatcher, error) {
+	m := &labelsMatcher{
+		labels: make(map[string]string, len(query)),
+	}
+	for key, value := range query {
+		if key != SelectorKey {
+			m.labels[key] = value
+			continue
+		}
+		selector, err := ParseSelector(value)
+		if err != nil {
+			return nil, err
+		}
+		m.selector = selector
+	}
+	return m, nil
+}
+
+func (m *labelsMatcher) match(labels map[string]string) bool {
+	if m.selector != nil && !m.selector.Matches(labels) {
+		return false
+	}
+	for key, value := range m.labels {
+		if val, ok := labels[key]; !ok || val != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *labelsMatcher) matchAny(where map[string]*registry.NetworkServiceLabels) bool {
+	for _, labels := range where {
+		if m.match(labels.GetLabels()) {
+			return true
+		}
+	}
+	return false
+}
+
+func compileName(query string) (func(string) bool, error) {
+	switch {
+	case query == "":
+		return func(string) bool { return true }, nil
+	case strings.HasPrefix(query, exactNamePrefix):
+		exact := strings.TrimPrefix(query, exactNamePrefix)
+		return func(name string) bool { return name == exact }, nil
+	case strings.HasPrefix(query, regexNamePrefix):
+		re, err := regexp.Compile(strings.TrimPrefix(query, regexNamePrefix))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid name regular expression: %s", query)
+		}
+		return re.MatchString, nil
+	default:
+		return func(name string) bool { return strings.Contains(name, query) }, nil
+	}
+}
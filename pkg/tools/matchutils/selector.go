@@ -0,0 +1,196 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchutils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SelectorKey - reserved label key, its value is a selector expression evaluated against the labels instead of the
+// exact match. It can be used in the query NSE labels and in the NS match source/destination selectors, e.g.:
+//
+//	"zone in (a, b), tier != canary, gpu, !spot"
+const SelectorKey = "nsm.selector"
+
+type operator int
+
+const (
+	equals operator = iota
+	notEquals
+	in
+	notIn
+	exists
+	notExists
+)
+
+var (
+	keyRegexp = regexp.MustCompile(`^[^\s=!(),]+$`)
+	setRegexp = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+type requirement struct {
+	key    string
+	op     operator
+	values map[string]struct{}
+}
+
+// Selector - parsed label selector expression
+type Selector struct {
+	requirements []*requirement
+}
+
+// ParseSelector - parses a selector expression of comma separated requirements, all of them should be satisfied:
+//
+//	key = value, key == value   - label is present and is equal to the value
+//	key != value                - label is absent or is not equal to the value
+//	key in (value1, value2)     - label is present and is equal to one of the values
+//	key notin (value1, value2)  - label is absent or is not equal to any of the values
+//	key                         - label is present
+//	!key                        - label is absent
+//
+// Empty expression matches everything.
+func ParseSelector(expr string) (*Selector, error) {
+	s := new(Selector)
+	if strings.TrimSpace(expr) == "" {
+		return s, nil
+	}
+	for _, part := range splitRequirements(expr) {
+		r, err := parseRequirement(strings.TrimSpace(part))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid selector: %s", expr)
+		}
+		s.requirements = append(s.requirements, r)
+	}
+	return s, nil
+}
+
+// Matches - returns true if labels satisfy all selector requirements
+func (s *Selector) Matches(labels map[string]string) bool {
+	for _, r := range s.requirements {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *requirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+	switch r.op {
+	case exists:
+		return ok
+	case notExists:
+		return !ok
+	case equals, in:
+		_, contains := r.values[value]
+		return ok && contains
+	default:
+		_, contains := r.values[value]
+		return !ok || !contains
+	}
+}
+
+// splitRequirements - splits expression by the commas outside of the parentheses
+func splitRequirements(expr string) (parts []string) {
+	depth, start := 0, 0
+	for i, c := range expr {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+func parseRequirement(str string) (*requirement, error) {
+	if str == "" {
+		return nil, errors.New("empty requirement")
+	}
+
+	if match := setRegexp.FindStringSubmatch(str); match != nil {
+		r := &requirement{
+			key:    match[1],
+			op:     in,
+			values: make(map[string]struct{}),
+		}
+		if match[2] == "notin" {
+			r.op = notIn
+		}
+		for _, value := range strings.Split(match[3], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				r.values[value] = struct{}{}
+			}
+		}
+		if len(r.values) == 0 {
+			return nil, errors.Errorf("empty set of values: %s", str)
+		}
+		return r.validate(str)
+	}
+
+	for _, op := range []struct {
+		token string
+		op    operator
+	}{
+		{"!=", notEquals},
+		{"==", equals},
+		{"=", equals},
+	} {
+		if i := strings.Index(str, op.token); i >= 0 {
+			return (&requirement{
+				key: strings.TrimSpace(str[:i]),
+				op:  op.op,
+				values: map[string]struct{}{
+					strings.TrimSpace(str[i+len(op.token):]): {},
+				},
+			}).validate(str)
+		}
+	}
+
+	if strings.HasPrefix(str, "!") {
+		return (&requirement{
+			key: strings.TrimSpace(str[1:]),
+			op:  notExists,
+		}).validate(str)
+	}
+
+	return (&requirement{
+		key: str,
+		op:  exists,
+	}).validate(str)
+}
+
+func (r *requirement) validate(str string) (*requirement, error) {
+	if !keyRegexp.MatchString(r.key) {
+		return nil, errors.Errorf("invalid key: %s", str)
+	}
+	for value := range r.values {
+		if strings.ContainsAny(value, "=!(),") {
+			return nil, errors.Errorf("invalid value: %s", str)
+		}
+	}
+	return r, nil
+}
@@ -20,42 +20,66 @@ package matchutils
 import (
 	"strings"
 
-	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/proto"
-
 	"github.com/networkservicemesh/api/pkg/api/registry"
 )
 
+const (
+	// AnyNetworkService - reserved network service name in the query NSE labels, its labels are matched against the
+	// labels of any network service of the NSE
+	AnyNetworkService = "*"
+
+	exactNamePrefix = "="
+	regexNamePrefix = "~"
+)
+
+// ExactName returns query name matching only the given name
+func ExactName(name string) string {
+	return exactNamePrefix + name
+}
+
+// ExactNameOf returns the name and true if the query name is created with ExactName
+func ExactNameOf(query string) (string, bool) {
+	if !strings.HasPrefix(query, exactNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(query, exactNamePrefix), true
+}
+
+// RegexName returns query name matching names by the regular expression
+func RegexName(expr string) string {
+	return regexNamePrefix + expr
+}
+
 // MatchNetworkServices returns true if two network services are matched
 func MatchNetworkServices(left, right *registry.NetworkService) bool {
-	return (left.Name == "" || strings.Contains(right.Name, left.Name)) &&
-		(left.Payload == "" || left.Payload == right.Payload) &&
-		(left.Matches == nil || cmp.Equal(left.Matches, right.Matches, cmp.Comparer(proto.Equal)))
+	m, err := NewNetworkServiceMatcher(left)
+	return err == nil && m.Match(right)
 }
 
 // MatchNetworkServiceEndpoints  returns true if two network service endpoints are matched
 func MatchNetworkServiceEndpoints(left, right *registry.NetworkServiceEndpoint) bool {
-	return (left.Name == "" || strings.Contains(right.Name, left.Name)) &&
-		(left.NetworkServiceLabels == nil || labelsContains(right.NetworkServiceLabels, left.NetworkServiceLabels)) &&
-		(left.ExpirationTime == nil || left.ExpirationTime.Seconds == right.ExpirationTime.Seconds) &&
-		(left.NetworkServiceNames == nil || contains(right.NetworkServiceNames, left.NetworkServiceNames)) &&
-		(left.Url == "" || strings.Contains(right.Url, left.Url))
+	m, err := NewNetworkServiceEndpointMatcher(left)
+	return err == nil && m.Match(right)
 }
 
-func labelsContains(where, what map[string]*registry.NetworkServiceLabels) bool {
-	for lService, lLabels := range what {
-		rService, ok := where[lService]
-		if !ok {
-			return false
-		}
-		for lKey, lVal := range lLabels.Labels {
-			rVal, ok := rService.Labels[lKey]
-			if !ok || lVal != rVal {
-				return false
-			}
-		}
-	}
-	return true
+// MatchLabels returns true if labels contain all the query labels, SelectorKey query label is evaluated as a selector
+// expression
+func MatchLabels(labels, query map[string]string) bool {
+	m, err := compileLabels(query)
+	return err == nil && m.match(labels)
+}
+
+// ValidateNetworkService returns an error if the query network service has invalid name regular expression
+func ValidateNetworkService(query *registry.NetworkService) error {
+	_, err := NewNetworkServiceMatcher(query)
+	return err
+}
+
+// ValidateNetworkServiceEndpoint returns an error if the query network service endpoint has invalid name regular
+// expression or invalid selector expressions
+func ValidateNetworkServiceEndpoint(query *registry.NetworkServiceEndpoint) error {
+	_, err := NewNetworkServiceEndpointMatcher(query)
+	return err
 }
 
 func contains(where, what []string) bool {
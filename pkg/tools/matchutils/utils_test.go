@@ -0,0 +1,187 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchutils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+func TestParseSelector(t *testing.T) {
+	labels := map[string]string{
+		"zone": "a",
+		"tier": "prod",
+		"gpu":  "true",
+	}
+
+	for expr, expected := range map[string]bool{
+		"":                                  true,
+		"zone=a":                            true,
+		"zone == a":                         true,
+		"zone = b":                          false,
+		"tier != canary":                    true,
+		"tier != prod":                      false,
+		"spot != true":                      true,
+		"zone in (a, b)":                    true,
+		"zone in (b, c)":                    false,
+		"spot in (true)":                    false,
+		"zone notin (b, c)":                 true,
+		"zone notin (a)":                    false,
+		"spot notin (true)":                 true,
+		"gpu":                               true,
+		"spot":                              false,
+		"!spot":                             true,
+		"!gpu":                              false,
+		"zone in (a, b), tier != canary":    true,
+		"zone in (a, b), tier notin (prod)": false,
+	} {
+		selector, err := matchutils.ParseSelector(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, expected, selector.Matches(labels), expr)
+	}
+}
+
+func TestParseSelector_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		",",
+		"zone=a,",
+		"zone in ()",
+		"zone in (a",
+		"= a",
+		"zone = (a)",
+		"!",
+	} {
+		_, err := matchutils.ParseSelector(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestMatchNetworkServiceEndpoints_Name(t *testing.T) {
+	nse := &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+	}
+
+	for name, expected := range map[string]bool{
+		"":                                true,
+		"nse":                             true,
+		matchutils.ExactName("nse"):       false,
+		matchutils.ExactName("nse-1"):     true,
+		matchutils.RegexName(`^nse-\d+$`): true,
+		matchutils.RegexName(`^nse-\D+$`): false,
+		matchutils.RegexName(`(`):         false,
+	} {
+		require.Equal(t, expected, matchutils.MatchNetworkServiceEndpoints(&registry.NetworkServiceEndpoint{Name: name}, nse), name)
+	}
+
+	require.Error(t, matchutils.ValidateNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+		Name: matchutils.RegexName(`(`),
+	}))
+}
+
+func TestMatchNetworkServiceEndpoints_Selector(t *testing.T) {
+	nse := &registry.NetworkServiceEndpoint{
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns-1": {
+				Labels: map[string]string{
+					"zone": "a",
+					"tier": "prod",
+				},
+			},
+			"ns-2": {
+				Labels: map[string]string{
+					"zone": "b",
+					"tier": "canary",
+				},
+			},
+		},
+	}
+
+	query := func(service string, labels map[string]string) *registry.NetworkServiceEndpoint {
+		return &registry.NetworkServiceEndpoint{
+			NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+				service: {Labels: labels},
+			},
+		}
+	}
+
+	// Exact labels match keeps working
+	require.True(t, matchutils.MatchNetworkServiceEndpoints(query("ns-1", map[string]string{"zone": "a"}), nse))
+	require.False(t, matchutils.MatchNetworkServiceEndpoints(query("ns-1", map[string]string{"zone": "b"}), nse))
+
+	selector := map[string]string{matchutils.SelectorKey: "zone in (a, b), tier != canary"}
+	require.True(t, matchutils.MatchNetworkServiceEndpoints(query("ns-1", selector), nse))
+	require.False(t, matchutils.MatchNetworkServiceEndpoints(query("ns-2", selector), nse))
+	require.False(t, matchutils.MatchNetworkServiceEndpoints(query("ns-3", selector), nse))
+	require.True(t, matchutils.MatchNetworkServiceEndpoints(query(matchutils.AnyNetworkService, selector), nse))
+	require.False(t, matchutils.MatchNetworkServiceEndpoints(query(matchutils.AnyNetworkService, map[string]string{
+		matchutils.SelectorKey: "zone = c",
+	}), nse))
+
+	invalid := query("ns-1", map[string]string{matchutils.SelectorKey: "zone in ("})
+	require.Error(t, matchutils.ValidateNetworkServiceEndpoint(invalid))
+	require.False(t, matchutils.MatchNetworkServiceEndpoints(invalid, nse))
+}
+
+func TestNetworkServiceEndpointMatcher(t *testing.T) {
+	matcher, err := matchutils.NewNetworkServiceEndpointMatcher(&registry.NetworkServiceEndpoint{
+		Name: matchutils.RegexName(`^nse-\d+$`),
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns": {
+				Labels: map[string]string{
+					matchutils.SelectorKey: "zone in (a, b)",
+					"tier":                 "prod",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	nse := func(name, zone string) *registry.NetworkServiceEndpoint {
+		return &registry.NetworkServiceEndpoint{
+			Name: name,
+			NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+				"ns": {
+					Labels: map[string]string{
+						"zone": zone,
+						"tier": "prod",
+					},
+				},
+			},
+		}
+	}
+
+	// The same matcher is reused for all the candidates
+	require.True(t, matcher.Match(nse("nse-1", "a")))
+	require.True(t, matcher.Match(nse("nse-2", "b")))
+	require.False(t, matcher.Match(nse("nse-3", "c")))
+	require.False(t, matcher.Match(nse("nse-x", "a")))
+
+	_, err = matchutils.NewNetworkServiceEndpointMatcher(&registry.NetworkServiceEndpoint{
+		Name: matchutils.RegexName(`(`),
+	})
+	require.Error(t, err)
+
+	_, err = matchutils.NewNetworkServiceMatcher(&registry.NetworkService{
+		Name: matchutils.RegexName(`(`),
+	})
+	require.Error(t, err)
+}
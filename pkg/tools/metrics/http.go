@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+const (
+	// Path - HTTP path to serve metrics on
+	Path = "/metrics"
+
+	shutdownTimeout = time.Second
+)
+
+// ListenAndServe - serves metrics from the gatherer over HTTP on the listenOn tcp address. Returns an error channel
+// which will receive an error and then be closed in the event that serving fails, or will be just closed on ctx done.
+func ListenAndServe(ctx context.Context, listenOn *url.URL, gatherer prometheus.Gatherer) <-chan error {
+	errCh := make(chan error, 1)
+
+	ln, err := net.Listen("tcp", listenOn.Host)
+	if err != nil {
+		errCh <- err
+		close(errCh)
+		return errCh
+	}
+	// We need to pass a real listener address, since we could specify random port.
+	*listenOn = *grpcutils.AddressToURL(ln.Addr())
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	return errCh
+}
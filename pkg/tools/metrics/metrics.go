@@ -0,0 +1,233 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides a set of utilities for collecting Prometheus metrics of the NSM chains
+package metrics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	namespace               = "nsm"
+	networkServiceSubsystem = "networkservice"
+
+	nameLabel           = "name"
+	elementLabel        = "element"
+	methodLabel         = "method"
+	codeLabel           = "code"
+	resultLabel         = "result"
+	networkServiceLabel = "network_service"
+	endpointLabel       = "network_service_endpoint"
+
+	successResult = "success"
+	failureResult = "failure"
+)
+
+type contextKeyType struct{}
+
+var (
+	defaultRegistry *prometheus.Registry
+	defaultMetrics  *Metrics
+	defaultOnce     sync.Once
+
+	isElementMetricsEnabled int32
+)
+
+// Metrics - NSM chains metrics registered with some Prometheus registry
+type Metrics struct {
+	*registryMetrics
+
+	requests          *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+	errors            *prometheus.CounterVec
+	activeConnections *prometheus.GaugeVec
+	elementDuration   *prometheus.HistogramVec
+	elementErrors     *prometheus.CounterVec
+	healAttempts      *prometheus.CounterVec
+	refreshFailures   *prometheus.CounterVec
+}
+
+// New - creates metrics and registers them with the registerer. If the metrics are already registered with the
+// registerer, the already registered collectors are reused, so it is safe to call New many times for the same
+// registerer.
+func New(registerer prometheus.Registerer) *Metrics {
+	return &Metrics{
+		registryMetrics: newRegistryMetrics(registerer),
+		requests: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "requests_total",
+			Help:      "Number of the Request and Close calls",
+		}, []string{nameLabel, networkServiceLabel, methodLabel})).(*prometheus.CounterVec),
+		requestDuration: register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the Request and Close calls",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{nameLabel, methodLabel})).(*prometheus.HistogramVec),
+		errors: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "errors_total",
+			Help:      "Number of the failed Request and Close calls by gRPC status code",
+		}, []string{nameLabel, methodLabel, codeLabel})).(*prometheus.CounterVec),
+		activeConnections: register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "active_connections",
+			Help:      "Number of the active connections",
+		}, []string{nameLabel, networkServiceLabel, endpointLabel})).(*prometheus.GaugeVec),
+		elementDuration: register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "element_duration_seconds",
+			Help:      "Latency of the Request and Close calls of the chain element including the rest of the chain",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{elementLabel, methodLabel})).(*prometheus.HistogramVec),
+		elementErrors: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "element_errors_total",
+			Help:      "Number of the failed Request and Close calls of the chain element by gRPC status code",
+		}, []string{elementLabel, methodLabel, codeLabel})).(*prometheus.CounterVec),
+		healAttempts: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "heal_attempts_total",
+			Help:      "Number of the heal attempts",
+		}, []string{networkServiceLabel, resultLabel})).(*prometheus.CounterVec),
+		refreshFailures: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: networkServiceSubsystem,
+			Name:      "refresh_failures_total",
+			Help:      "Number of the failed refresh Requests",
+		}, []string{networkServiceLabel})).(*prometheus.CounterVec),
+	}
+}
+
+func register(registerer prometheus.Registerer, collector prometheus.Collector) prometheus.Collector {
+	if err := registerer.Register(collector); err != nil {
+		if alreadyRegisteredErr, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return alreadyRegisteredErr.ExistingCollector
+		}
+		panic(err.Error())
+	}
+	return collector
+}
+
+// Registry - returns default Prometheus registry with all NSM metrics, Go runtime and process metrics registered
+func Registry() *prometheus.Registry {
+	initDefault()
+	return defaultRegistry
+}
+
+// Default - returns metrics registered with the default registry
+func Default() *Metrics {
+	initDefault()
+	return defaultMetrics
+}
+
+func initDefault() {
+	defaultOnce.Do(func() {
+		defaultRegistry = prometheus.NewRegistry()
+		defaultRegistry.MustRegister(
+			prometheus.NewGoCollector(),
+			prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		)
+		defaultMetrics = New(defaultRegistry)
+	})
+}
+
+// WithMetrics - returns a new context with metrics
+func WithMetrics(ctx context.Context, metrics *Metrics) context.Context {
+	return context.WithValue(ctx, contextKeyType{}, metrics)
+}
+
+// FromContext - returns metrics from the context, or default metrics if there are no metrics in the context
+func FromContext(ctx context.Context) *Metrics {
+	if metrics, ok := ctx.Value(contextKeyType{}).(*Metrics); ok {
+		return metrics
+	}
+	return Default()
+}
+
+// IsElementMetricsEnabled - returns true if chains should collect metrics for every chain element
+func IsElementMetricsEnabled() bool {
+	return atomic.LoadInt32(&isElementMetricsEnabled) != 0
+}
+
+// EnableElementMetrics - enable/disable collecting metrics for every chain element
+func EnableElementMetrics(enable bool) {
+	var value int32
+	if enable {
+		value = 1
+	}
+	atomic.StoreInt32(&isElementMetricsEnabled, value)
+}
+
+// ObserveRequest - counts the Request or Close call of the chain with the name, its latency and error
+func (m *Metrics) ObserveRequest(name, networkService, method string, start time.Time, err error) {
+	m.requests.WithLabelValues(name, networkService, method).Inc()
+	m.requestDuration.WithLabelValues(name, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(name, method, code(err)).Inc()
+	}
+}
+
+// ObserveElement - counts the Request or Close call latency and error of the chain element
+func (m *Metrics) ObserveElement(element, method string, start time.Time, err error) {
+	m.elementDuration.WithLabelValues(element, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.elementErrors.WithLabelValues(element, method, code(err)).Inc()
+	}
+}
+
+// ConnectionOpened - increments the number of active connections for the network service and endpoint
+func (m *Metrics) ConnectionOpened(name, networkService, endpoint string) {
+	m.activeConnections.WithLabelValues(name, networkService, endpoint).Inc()
+}
+
+// ConnectionClosed - decrements the number of active connections for the network service and endpoint
+func (m *Metrics) ConnectionClosed(name, networkService, endpoint string) {
+	m.activeConnections.WithLabelValues(name, networkService, endpoint).Dec()
+}
+
+// HealAttempt - counts the heal attempt for the network service
+func (m *Metrics) HealAttempt(networkService string, err error) {
+	result := successResult
+	if err != nil {
+		result = failureResult
+	}
+	m.healAttempts.WithLabelValues(networkService, result).Inc()
+}
+
+// RefreshFailure - counts the failed refresh Request for the network service
+func (m *Metrics) RefreshFailure(networkService string) {
+	m.refreshFailures.WithLabelValues(networkService).Inc()
+}
+
+func code(err error) string {
+	return status.Code(errors.Cause(err)).String()
+}
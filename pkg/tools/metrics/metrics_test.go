@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+func TestNew_AlreadyRegistered(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	metrics.New(registry).HealAttempt("ns", nil)
+	metrics.New(registry).HealAttempt("ns", nil)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var found bool
+	for _, family := range families {
+		if family.GetName() == "nsm_networkservice_heal_attempts_total" {
+			require.Len(t, family.GetMetric(), 1)
+			require.Equal(t, 2., family.GetMetric()[0].GetCounter().GetValue())
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestFromContext(t *testing.T) {
+	m := metrics.New(prometheus.NewRegistry())
+
+	require.Equal(t, m, metrics.FromContext(metrics.WithMetrics(context.Background(), m)))
+	require.Equal(t, metrics.Default(), metrics.FromContext(context.Background()))
+}
+
+func TestListenAndServe(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	registry := prometheus.NewRegistry()
+	metrics.New(registry).ObserveRequest("nsmgr", "ns", "Request", time.Now(), nil)
+
+	listenOn := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	errCh := metrics.ListenAndServe(ctx, listenOn, registry)
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Get(fmt.Sprintf("http://%s%s", listenOn.Host, metrics.Path))
+	require.NoError(t, err)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(body),
+		`nsm_networkservice_requests_total{method="Request",name="nsmgr",network_service="ns"} 1`), string(body))
+
+	cancel()
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+}
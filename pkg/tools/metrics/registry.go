@@ -0,0 +1,161 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	registrySubsystem = "registry"
+
+	kindLabel = "kind"
+)
+
+// Kind - kind of the registry resource
+type Kind string
+
+const (
+	// NetworkServiceKind - registry.NetworkService
+	NetworkServiceKind Kind = "network_service"
+	// EndpointKind - registry.NetworkServiceEndpoint
+	EndpointKind Kind = "network_service_endpoint"
+)
+
+type registryMetrics struct {
+	registered       *prometheus.GaugeVec
+	registryRequests *prometheus.CounterVec
+	registryDuration *prometheus.HistogramVec
+	registryErrors   *prometheus.CounterVec
+	watchSubscribers *prometheus.GaugeVec
+	expired          *prometheus.CounterVec
+	queryCache       *prometheus.CounterVec
+	connectClients   *prometheus.GaugeVec
+}
+
+func newRegistryMetrics(registerer prometheus.Registerer) *registryMetrics {
+	return &registryMetrics{
+		registered: register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "registered",
+			Help:      "Number of the registered network services and endpoints",
+		}, []string{nameLabel, kindLabel})).(*prometheus.GaugeVec),
+		registryRequests: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "requests_total",
+			Help:      "Number of the Register, Unregister and Find calls",
+		}, []string{nameLabel, kindLabel, methodLabel})).(*prometheus.CounterVec),
+		registryDuration: register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the Register and Unregister calls",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{nameLabel, kindLabel, methodLabel})).(*prometheus.HistogramVec),
+		registryErrors: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "errors_total",
+			Help:      "Number of the failed Register, Unregister and Find calls by gRPC status code",
+		}, []string{nameLabel, kindLabel, methodLabel, codeLabel})).(*prometheus.CounterVec),
+		watchSubscribers: register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "watch_subscribers",
+			Help:      "Number of the active watching Find calls",
+		}, []string{nameLabel, kindLabel})).(*prometheus.GaugeVec),
+		expired: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "expired_total",
+			Help:      "Number of the network services and endpoints unregistered because of expiration",
+		}, []string{kindLabel})).(*prometheus.CounterVec),
+		queryCache: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "querycache_requests_total",
+			Help:      "Number of the Find queries resolved with the query cache by result: hit or miss",
+		}, []string{resultLabel})).(*prometheus.CounterVec),
+		connectClients: register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: registrySubsystem,
+			Name:      "connect_clients",
+			Help:      "Number of the registry clients cached by the connect servers",
+		}, []string{kindLabel})).(*prometheus.GaugeVec),
+	}
+}
+
+// ObserveRegistryRequest - counts the Register, Unregister or Find call of the registry chain with the name, its
+// latency and error. Latency is not observed for the Find calls, since watching Find can last forever.
+func (m *registryMetrics) ObserveRegistryRequest(name string, kind Kind, method string, start time.Time, err error) {
+	m.registryRequests.WithLabelValues(name, string(kind), method).Inc()
+	if method != "Find" {
+		m.registryDuration.WithLabelValues(name, string(kind), method).Observe(time.Since(start).Seconds())
+	}
+	if err != nil {
+		m.registryErrors.WithLabelValues(name, string(kind), method, code(err)).Inc()
+	}
+}
+
+// Registered - increments the number of the registered resources of the kind
+func (m *registryMetrics) Registered(name string, kind Kind) {
+	m.registered.WithLabelValues(name, string(kind)).Inc()
+}
+
+// Unregistered - decrements the number of the registered resources of the kind
+func (m *registryMetrics) Unregistered(name string, kind Kind) {
+	m.registered.WithLabelValues(name, string(kind)).Dec()
+}
+
+// WatchStarted - increments the number of the watch subscribers
+func (m *registryMetrics) WatchStarted(name string, kind Kind) {
+	m.watchSubscribers.WithLabelValues(name, string(kind)).Inc()
+}
+
+// WatchFinished - decrements the number of the watch subscribers
+func (m *registryMetrics) WatchFinished(name string, kind Kind) {
+	m.watchSubscribers.WithLabelValues(name, string(kind)).Dec()
+}
+
+// Expired - counts the resource of the kind unregistered because of expiration
+func (m *registryMetrics) Expired(kind Kind) {
+	m.expired.WithLabelValues(string(kind)).Inc()
+}
+
+// QueryCacheHit - counts the Find query resolved from the query cache
+func (m *registryMetrics) QueryCacheHit() {
+	m.queryCache.WithLabelValues("hit").Inc()
+}
+
+// QueryCacheMiss - counts the Find query not found in the query cache
+func (m *registryMetrics) QueryCacheMiss() {
+	m.queryCache.WithLabelValues("miss").Inc()
+}
+
+// ConnectClientAdded - increments the number of the registry clients cached by the connect servers
+func (m *registryMetrics) ConnectClientAdded(kind Kind) {
+	m.connectClients.WithLabelValues(string(kind)).Inc()
+}
+
+// ConnectClientRemoved - decrements the number of the registry clients cached by the connect servers
+func (m *registryMetrics) ConnectClientRemoved(kind Kind) {
+	m.connectClients.WithLabelValues(string(kind)).Dec()
+}
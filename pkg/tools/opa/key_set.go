@@ -0,0 +1,49 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// KeySet - keys to verify token signatures with
+type KeySet struct {
+	// HMACSecrets - secrets to verify HS256 signed tokens
+	HMACSecrets []string
+	// PublicKeys - PEM encoded P-256 ECDSA public keys to verify ES256 signed tokens
+	PublicKeys []string
+}
+
+// regoRules - returns rego rules declaring the key set and the token_signed(token) function
+func (ks *KeySet) regoRules() string {
+	hmacSecrets, _ := json.Marshal(append([]string{}, ks.HMACSecrets...))
+	publicKeys, _ := json.Marshal(append([]string{}, ks.PublicKeys...))
+	return fmt.Sprintf(`
+hmac_secrets = %s
+
+public_keys = %s
+
+token_signed(token) {
+	io.jwt.verify_hs256(token, hmac_secrets[_])
+}
+
+token_signed(token) {
+	io.jwt.verify_es256(token, public_keys[_])
+}
+`, hmacSecrets, publicKeys)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const lastTokenSignedByKeysPolicy = `
+package policies
+
+default last_token_signed_by_keys = false
+default index = 0
+
+index = input.index
+
+last_token_signed_by_keys {
+	next_index = index + 1
+	next_index < count(input.path_segments)
+	token_signed(input.path_segments[next_index].token)
+}
+
+last_token_signed_by_keys {
+	index < count(input.path_segments)
+	token_signed(input.path_segments[index].token)
+}
+`
+
+// WithLastTokenSignedByKeysPolicy returns policy for checking that last token in path is signed by one of the keys
+// from the key set. It is the WithLastTokenSignedPolicy alternative for the tokens not signed by the peer certificate.
+func WithLastTokenSignedByKeysPolicy(keys *KeySet) *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		policySource: lastTokenSignedByKeysPolicy + keys.regoRules(),
+		query:        "last_token_signed_by_keys",
+		checker:      True("last_token_signed_by_keys"),
+	}
+}
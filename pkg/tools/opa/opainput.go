@@ -22,34 +22,104 @@ import (
 	"encoding/json"
 	"encoding/pem"
 
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/pkg/errors"
-
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 
-	"google.golang.org/grpc/credentials"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
-// PreparedOpaInput - converts model to map. It also puts auth_info in root of the map if it is presented in context.
+// connectionInput - OPA input for the network service Request/Close: path fields are kept in the root of the input for
+// compatibility with the policies expecting *networkservice.Path as input
+type connectionInput struct {
+	*networkservice.Path
+	NetworkService       string                      `json:"network_service,omitempty"`
+	Labels               map[string]string           `json:"labels,omitempty"`
+	Mechanism            *networkservice.Mechanism   `json:"mechanism,omitempty"`
+	MechanismPreferences []*networkservice.Mechanism `json:"mechanism_preferences,omitempty"`
+}
+
+// PreparedOpaInput - converts model to map. *networkservice.NetworkServiceRequest and *networkservice.Connection are
+// converted to the path fields with network_service, labels, mechanism and mechanism_preferences added. Decoded claims
+// are added to every path segment with a JWT token. It also puts auth_info in root of the map if it is presented in
+// context: peer certificate, peer SPIFFE ID and peer token passed with the per RPC credentials.
 func PreparedOpaInput(ctx context.Context, model interface{}) (map[string]interface{}, error) {
-	result, err := convertToMap(model)
+	result, err := convertToMap(toInputModel(model))
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot convert %v to map", model)
 	}
+	addTokenClaims(result)
+
 	p, ok := peer.FromContext(ctx)
 	var cert *x509.Certificate
 	if ok {
 		cert = parseX509Cert(p.AuthInfo)
 	}
-	var pemcert string
+	authInfo := map[string]interface{}{
+		"certificate": "",
+	}
 	if cert != nil {
-		pemcert = pemEncodingX509Cert(cert)
+		authInfo["certificate"] = pemEncodingX509Cert(cert)
+		if spiffeID, err := x509svid.IDFromCert(cert); err == nil {
+			authInfo["spiffe_id"] = spiffeID.String()
+		}
 	}
-	result["auth_info"] = map[string]interface{}{
-		"certificate": pemcert,
+	if tok, _, err := token.FromContext(ctx); err == nil {
+		authInfo["token"] = tok
 	}
+	result["auth_info"] = authInfo
 	return result, nil
 }
 
+func toInputModel(model interface{}) interface{} {
+	switch m := model.(type) {
+	case *networkservice.NetworkServiceRequest:
+		return &connectionInput{
+			Path:                 m.GetConnection().GetPath(),
+			NetworkService:       m.GetConnection().GetNetworkService(),
+			Labels:               m.GetConnection().GetLabels(),
+			Mechanism:            m.GetConnection().GetMechanism(),
+			MechanismPreferences: m.GetMechanismPreferences(),
+		}
+	case *networkservice.Connection:
+		return &connectionInput{
+			Path:           m.GetPath(),
+			NetworkService: m.GetNetworkService(),
+			Labels:         m.GetLabels(),
+			Mechanism:      m.GetMechanism(),
+		}
+	default:
+		return model
+	}
+}
+
+// addTokenClaims - adds "claims" to every path segment with a token which can be decoded as JWT. Token signature is
+// not verified here, it is up to the policies.
+func addTokenClaims(input map[string]interface{}) {
+	segments, ok := input["path_segments"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, s := range segments {
+		segment, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		tok, ok := segment["token"].(string)
+		if !ok || tok == "" {
+			continue
+		}
+		claims := jwt.MapClaims{}
+		if _, _, err := new(jwt.Parser).ParseUnverified(tok, claims); err != nil {
+			continue
+		}
+		segment["claims"] = map[string]interface{}(claims)
+	}
+}
+
 func pemEncodingX509Cert(cert *x509.Certificate) string {
 	certpem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
 	return string(certpem)
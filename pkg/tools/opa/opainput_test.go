@@ -22,10 +22,14 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"testing"
+	"time"
 
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 
@@ -88,6 +92,7 @@ func TestPreparedOpaInput(t *testing.T) {
 		},
 		"auth_info": map[string]interface{}{
 			"certificate": certPem,
+			"spiffe_id":   spiffeID,
 		},
 	}
 
@@ -95,3 +100,92 @@ func TestPreparedOpaInput(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedInput, realInput)
 }
+
+func TestPreparedOpaInput_Token(t *testing.T) {
+	testToken := "testToken"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"nsm-client-token", testToken,
+		"nsm-client-token-expires", time.Now().Add(time.Hour).Format(time.RFC3339Nano),
+	))
+
+	realInput, err := opa.PreparedOpaInput(ctx, &registry.NetworkServiceEndpoint{Name: "nse"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name": "nse",
+		"auth_info": map[string]interface{}{
+			"certificate": "",
+			"token":       testToken,
+		},
+	}, realInput)
+}
+
+func TestPreparedOpaInput_Request(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	testToken := genJWTWithClaims(&jwt.StandardClaims{
+		Subject:   "spiffe://test.com/nsc",
+		Audience:  "spiffe://test.com/nsmgr",
+		ExpiresAt: time.Date(nextYear, 1, 1, 1, 1, 1, 1, time.UTC).Unix(),
+	})
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: "ns",
+			Labels:         map[string]string{"app": "nsc"},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{
+						Name:  "nsc",
+						Token: testToken,
+					},
+				},
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: "LOCAL", Type: "KERNEL"},
+		},
+	}
+
+	realInput, err := opa.PreparedOpaInput(context.Background(), request)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"path_segments": []interface{}{
+			map[string]interface{}{
+				"name":  "nsc",
+				"token": testToken,
+				"claims": map[string]interface{}{
+					"sub": "spiffe://test.com/nsc",
+					"aud": "spiffe://test.com/nsmgr",
+					"exp": float64(time.Date(nextYear, 1, 1, 1, 1, 1, 1, time.UTC).Unix()),
+				},
+			},
+		},
+		"network_service": "ns",
+		"labels": map[string]interface{}{
+			"app": "nsc",
+		},
+		"mechanism_preferences": []interface{}{
+			map[string]interface{}{
+				"cls":  "LOCAL",
+				"type": "KERNEL",
+			},
+		},
+		"auth_info": map[string]interface{}{
+			"certificate": "",
+		},
+	}, realInput)
+
+	p := opa.WithPolicyFromSource(`
+		package test
+
+		default allow = false
+
+		allow {
+			input.network_service == "ns"
+			input.path_segments[0].claims.sub == "spiffe://test.com/nsc"
+		}
+`, "allow", opa.True)
+	assert.Nil(t, p.Check(context.Background(), request))
+
+	request.GetConnection().NetworkService = "other-ns"
+	assert.NotNil(t, p.Check(context.Background(), request))
+}
@@ -18,6 +18,8 @@ package opa
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
@@ -27,6 +29,9 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 // CheckAccessFunc checks rego result. Returns bool flag that means access. Returns error if something was wrong
@@ -74,13 +79,34 @@ func WithPolicyFromSource(source, query string, checkQuery CheckQueryFunc) *Auth
 	}
 }
 
+// PolicyOption is an option for the file based policy
+type PolicyOption func(p *AuthorizationPolicy)
+
+// WithHotReload watches the policy file until ctx is done and recompiles the policy on each file change. If the new
+// source fails to compile, the last successfully compiled policy stays active.
+func WithHotReload(ctx context.Context) PolicyOption {
+	return func(p *AuthorizationPolicy) {
+		p.watchCtx = ctx
+	}
+}
+
 // WithPolicyFromFile creates custom policy based on rego source file
-func WithPolicyFromFile(path, query string, checkQuery CheckQueryFunc) *AuthorizationPolicy {
-	return &AuthorizationPolicy{
+func WithPolicyFromFile(path, query string, checkQuery CheckQueryFunc, options ...PolicyOption) *AuthorizationPolicy {
+	if query == "" {
+		query = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	p := &AuthorizationPolicy{
 		policyFilePath: path,
 		query:          query,
 		checker:        checkQuery(query),
 	}
+	for _, opt := range options {
+		opt(p)
+	}
+	if p.watchCtx != nil {
+		go p.watch(p.watchCtx)
+	}
+	return p
 }
 
 // AuthorizationPolicy checks that passed tokens are valid
@@ -88,11 +114,13 @@ type AuthorizationPolicy struct {
 	initErr        error
 	policyFilePath string
 	policySource   string
-	pkg            string
 	query          string
 	evalQuery      *rego.PreparedEvalQuery
+	revision       string
 	checker        CheckAccessFunc
+	watchCtx       context.Context
 	once           sync.Once
+	mu             sync.RWMutex
 }
 
 // Check returns nil if passed tokens are valid
@@ -104,7 +132,7 @@ func (d *AuthorizationPolicy) Check(ctx context.Context, model interface{}) erro
 	if intErr := d.init(); intErr != nil {
 		return intErr
 	}
-	rs, err := d.evalQuery.Eval(ctx, rego.EvalInput(input))
+	rs, err := d.getEvalQuery().Eval(ctx, rego.EvalInput(input))
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -118,59 +146,109 @@ func (d *AuthorizationPolicy) Check(ctx context.Context, model interface{}) erro
 	return nil
 }
 
+// Revision returns revision of the active policy: hash of its source, or empty string if the policy is not compiled
+func (d *AuthorizationPolicy) Revision() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.revision
+}
+
 func (d *AuthorizationPolicy) init() error {
 	d.once.Do(func() {
 		if d.query == "" {
 			d.query = strings.TrimSuffix(filepath.Base(d.policyFilePath), filepath.Ext(d.policyFilePath))
 		}
-		if d.initErr = d.loadSource(); d.initErr != nil {
-			return
-		}
-		if d.initErr = d.checkModule(); d.initErr != nil {
+		if d.getEvalQuery() != nil {
+			// Already compiled by the file watcher
 			return
 		}
-		var r rego.PreparedEvalQuery
-		r, d.initErr = rego.New(
-			rego.Query(strings.Join([]string{"data", d.pkg, d.query}, ".")),
-			rego.Module(d.pkg, d.policySource)).PrepareForEval(context.Background())
-		if d.initErr != nil {
+		var source string
+		if source, d.initErr = d.loadSource(); d.initErr != nil {
 			return
 		}
-		d.evalQuery = &r
+		d.initErr = d.compile(source)
 	})
+	if d.getEvalQuery() != nil {
+		return nil
+	}
 	if d.initErr != nil {
 		return d.initErr
 	}
-	if d.evalQuery == nil {
-		return errors.Errorf("policy %v is not compiled", d.policySource)
-	}
-	return nil
+	source, _ := d.loadSource()
+	return errors.Errorf("policy %v is not compiled", source)
 }
 
-func (d *AuthorizationPolicy) loadSource() error {
-	if d.policySource != "" {
-		return nil
+func (d *AuthorizationPolicy) getEvalQuery() *rego.PreparedEvalQuery {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.evalQuery
+}
+
+func (d *AuthorizationPolicy) compile(source string) error {
+	source = strings.TrimSpace(source)
+
+	pkg, err := packageName(source)
+	if err != nil {
+		return err
 	}
-	var b []byte
-	b, err := ioutil.ReadFile(d.policyFilePath)
+
+	r, err := rego.New(
+		rego.Query(strings.Join([]string{"data", pkg, d.query}, ".")),
+		rego.Module(pkg, source)).PrepareForEval(context.Background())
 	if err != nil {
 		return err
 	}
-	d.policySource = strings.TrimSpace(string(b))
+
+	sum := sha256.Sum256([]byte(source))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.policySource = source
+	d.evalQuery = &r
+	d.revision = hex.EncodeToString(sum[:8])
 	return nil
 }
 
-func (d *AuthorizationPolicy) checkModule() error {
-	if d.pkg != "" {
-		return nil
+func (d *AuthorizationPolicy) watch(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("opa.AuthorizationPolicy", d.policyFilePath)
+	for source := range fs.WatchFile(ctx, d.policyFilePath) {
+		if source == nil {
+			logger.Warnf("policy file is removed, keeping revision %s", d.Revision())
+			continue
+		}
+		if err := d.compile(string(source)); err != nil {
+			logger.Errorf("failed to compile policy, keeping revision %s: %s", d.Revision(), err.Error())
+			continue
+		}
+		logger.Infof("policy revision %s is active", d.Revision())
+	}
+}
+
+func (d *AuthorizationPolicy) loadSource() (string, error) {
+	d.mu.RLock()
+	source := d.policySource
+	d.mu.RUnlock()
+
+	if source != "" {
+		return source, nil
 	}
+	b, err := ioutil.ReadFile(d.policyFilePath)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func packageName(source string) (string, error) {
 	const pkg = "package"
-	lines := strings.Split(d.policySource, "\n")
+	lines := strings.Split(source, "\n")
 	for i := 0; i < len(lines); i++ {
 		if strings.HasPrefix(lines[i], pkg) {
-			d.pkg = strings.TrimSpace(lines[i][len(pkg):])
-			return nil
+			return strings.TrimSpace(lines[i][len(pkg):]), nil
 		}
 	}
-	return errors.New("missed package")
+	return "", errors.New("missed package")
 }
@@ -23,8 +23,11 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 )
@@ -53,3 +56,61 @@ func TestWithPolicyFromFile(t *testing.T) {
 	err = p.Check(context.Background(), nil)
 	require.Nil(t, err)
 }
+
+func TestWithPolicyFromFile_HotReload(t *testing.T) {
+	dir := filepath.Clean(path.Join(os.TempDir(), t.Name()))
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const allowPolicy = `
+		package test
+
+		default allow = true
+	`
+	const denyPolicy = `
+		package test
+
+		default allow = false
+	`
+	const invalidPolicy = `
+		package test
+
+		default allow =
+	`
+
+	policyPath := filepath.Clean(path.Join(dir, "policy.rego"))
+	err = ioutil.WriteFile(policyPath, []byte(allowPolicy), os.ModePerm)
+	require.NoError(t, err)
+
+	p := opa.WithPolicyFromFile(policyPath, "allow", opa.True, opa.WithHotReload(ctx))
+
+	require.NoError(t, p.Check(context.Background(), nil))
+	require.Eventually(t, func() bool {
+		return p.Revision() != ""
+	}, time.Second, 10*time.Millisecond)
+	allowRevision := p.Revision()
+
+	err = ioutil.WriteFile(policyPath, []byte(denyPolicy), os.ModePerm)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return status.Code(p.Check(context.Background(), nil)) == codes.PermissionDenied
+	}, time.Second, 10*time.Millisecond)
+	denyRevision := p.Revision()
+	require.NotEqual(t, allowRevision, denyRevision)
+
+	err = ioutil.WriteFile(policyPath, []byte(invalidPolicy), os.ModePerm)
+	require.NoError(t, err)
+
+	require.Never(t, func() bool {
+		return p.Revision() != denyRevision
+	}, 200*time.Millisecond, 10*time.Millisecond)
+	require.Equal(t, codes.PermissionDenied, status.Code(p.Check(context.Background(), nil)))
+}
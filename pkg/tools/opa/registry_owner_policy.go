@@ -0,0 +1,43 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const registryOwnerPolicy = `
+package policies
+
+default registry_owner_valid = false
+
+registry_owner_valid {
+	not input.owner
+}
+
+registry_owner_valid {
+	input.auth_info.spiffe_id == input.owner
+}
+`
+
+// WithRegistryOwnerPolicy returns default registry policy for checking that the peer owns the registry entry: the
+// peer SPIFFE ID should be equal to the owner of the already registered entry. Token subject is not used, since the
+// peer can sign a token with any subject. Entries without an owner (new ones) are allowed for any peer.
+func WithRegistryOwnerPolicy() *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		policySource: registryOwnerPolicy,
+		query:        "registry_owner_valid",
+		checker:      True("registry_owner_valid"),
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+func withIncomingToken(ctx context.Context, tok string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(
+		"nsm-client-token", tok,
+		"nsm-client-token-expires", time.Now().Add(time.Hour).Format(time.RFC3339Nano),
+	))
+}
+
+func TestRegistryPolicies(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	lastYear := time.Now().Year() - 1
+
+	ca, err := generateCA()
+	require.NoError(t, err)
+	cert, err := generateKeyPair(spiffeID, "test.com", &ca)
+	require.NoError(t, err)
+	x509crt, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	signedToken, err := jwt.New(jwt.SigningMethodES256).SignedString(cert.PrivateKey)
+	require.NoError(t, err)
+
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{x509crt},
+			},
+		},
+	})
+
+	suits := []struct {
+		name    string
+		policy  *opa.AuthorizationPolicy
+		ctx     context.Context
+		allowed bool
+	}{
+		{
+			name:    "valid token",
+			policy:  opa.WithRegistryTokenValidPolicy(),
+			ctx:     withIncomingToken(context.Background(), genJWTWithClaimsWithYear(nextYear)),
+			allowed: true,
+		},
+		{
+			name:    "invalid token",
+			policy:  opa.WithRegistryTokenValidPolicy(),
+			ctx:     withIncomingToken(context.Background(), "invalid"),
+			allowed: false,
+		},
+		{
+			name:    "missing token",
+			policy:  opa.WithRegistryTokenValidPolicy(),
+			ctx:     context.Background(),
+			allowed: false,
+		},
+		{
+			name:    "not expired token",
+			policy:  opa.WithRegistryTokenExpiredPolicy(),
+			ctx:     withIncomingToken(context.Background(), genJWTWithClaimsWithYear(nextYear)),
+			allowed: true,
+		},
+		{
+			name:    "expired token",
+			policy:  opa.WithRegistryTokenExpiredPolicy(),
+			ctx:     withIncomingToken(context.Background(), genJWTWithClaimsWithYear(lastYear)),
+			allowed: false,
+		},
+		{
+			name:    "token signed by the peer",
+			policy:  opa.WithRegistryTokenSignedPolicy(),
+			ctx:     withIncomingToken(peerCtx, signedToken),
+			allowed: true,
+		},
+		{
+			name:    "token not signed by the peer",
+			policy:  opa.WithRegistryTokenSignedPolicy(),
+			ctx:     withIncomingToken(peerCtx, genJWTWithClaimsWithYear(nextYear)),
+			allowed: false,
+		},
+		{
+			name:    "signed token without the peer",
+			policy:  opa.WithRegistryTokenSignedPolicy(),
+			ctx:     withIncomingToken(context.Background(), signedToken),
+			allowed: false,
+		},
+	}
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+	}
+
+	for i := range suits {
+		s := suits[i]
+		t.Run(s.name, func(t *testing.T) {
+			err = s.policy.Check(s.ctx, nse)
+			if s.allowed {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			st, ok := status.FromError(err)
+			require.True(t, ok, "error without error status code")
+			require.Equal(t, codes.PermissionDenied, st.Code(), "wrong error status code")
+		})
+	}
+}
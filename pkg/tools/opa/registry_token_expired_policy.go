@@ -0,0 +1,43 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const registryTokenExpiredPolicy = `
+package policies
+
+default registry_token_expired = false
+
+registry_token_expired {
+	[_, payload, _] := io.jwt.decode(input.auth_info.token)
+	now > payload.exp
+}
+
+now = t {
+	ns := time.now_ns()
+	t := ns / 1e9
+}
+`
+
+// WithRegistryTokenExpiredPolicy returns default registry policy for checking the peer token expiration
+func WithRegistryTokenExpiredPolicy() *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		policySource: registryTokenExpiredPolicy,
+		query:        "registry_token_expired",
+		checker:      False("registry_token_expired"),
+	}
+}
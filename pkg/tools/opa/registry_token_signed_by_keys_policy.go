@@ -0,0 +1,38 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const registryTokenSignedByKeysPolicy = `
+package policies
+
+default registry_token_signed_by_keys = false
+
+registry_token_signed_by_keys {
+	token_signed(input.auth_info.token)
+}
+`
+
+// WithRegistryTokenSignedByKeysPolicy returns registry policy for checking that the peer token is signed by one of
+// the keys from the key set
+func WithRegistryTokenSignedByKeysPolicy(keys *KeySet) *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		policySource: registryTokenSignedByKeysPolicy + keys.regoRules(),
+		query:        "registry_token_signed_by_keys",
+		checker:      True("registry_token_signed_by_keys"),
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const registryTokenSignedPolicy = `
+package policies
+
+default registry_token_signed = false
+
+registry_token_signed {
+	io.jwt.verify_es256(input.auth_info.token, input.auth_info.certificate) = true
+}
+`
+
+// WithRegistryTokenSignedPolicy returns default registry policy for checking that the peer token is signed by the
+// peer certificate key.
+func WithRegistryTokenSignedPolicy() *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		policySource: registryTokenSignedPolicy,
+		query:        "registry_token_signed",
+		checker:      True("registry_token_signed"),
+	}
+}
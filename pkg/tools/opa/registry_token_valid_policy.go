@@ -0,0 +1,38 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const registryTokenValidPolicy = `
+package policies
+
+default registry_token_valid = false
+
+registry_token_valid {
+	[_, _, _] := io.jwt.decode(input.auth_info.token)
+}
+`
+
+// WithRegistryTokenValidPolicy returns default registry policy for checking that the peer has passed a token and it
+// can be decoded.
+func WithRegistryTokenValidPolicy() *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		policySource: registryTokenValidPolicy,
+		query:        "registry_token_valid",
+		checker:      True("registry_token_valid"),
+	}
+}
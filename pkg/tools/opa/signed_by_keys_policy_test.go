@@ -0,0 +1,119 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/staticjwt"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	b, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+}
+
+func pathWithToken(t *testing.T, genTokenFunc token.GeneratorFunc) *networkservice.Path {
+	tok, _, err := genTokenFunc(nil)
+	require.NoError(t, err)
+	return &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Token: tok,
+			},
+		},
+	}
+}
+
+func TestLastTokenSignedByKeysPolicy(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p := opa.WithLastTokenSignedByKeysPolicy(&opa.KeySet{
+		HMACSecrets: []string{"secret"},
+		PublicKeys:  []string{publicKeyPEM(t, key)},
+	})
+
+	suits := []struct {
+		name         string
+		genTokenFunc token.GeneratorFunc
+		allowed      bool
+	}{
+		{
+			name:         "HMAC signed with known secret",
+			genTokenFunc: staticjwt.HMACTokenGeneratorFunc("secret", staticjwt.WithSubject("nsc")),
+			allowed:      true,
+		},
+		{
+			name:         "HMAC signed with unknown secret",
+			genTokenFunc: staticjwt.HMACTokenGeneratorFunc("other secret"),
+			allowed:      false,
+		},
+		{
+			name:         "ECDSA signed with known key",
+			genTokenFunc: staticjwt.ECDSATokenGeneratorFunc(key, staticjwt.WithSubject("nsc")),
+			allowed:      true,
+		},
+		{
+			name:         "ECDSA signed with unknown key",
+			genTokenFunc: staticjwt.ECDSATokenGeneratorFunc(otherKey),
+			allowed:      false,
+		},
+	}
+
+	for i := range suits {
+		s := suits[i]
+		t.Run(s.name, func(t *testing.T) {
+			err := p.Check(context.Background(), pathWithToken(t, s.genTokenFunc))
+			if s.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistryTokenSignedByKeysPolicy(t *testing.T) {
+	p := opa.WithRegistryTokenSignedByKeysPolicy(&opa.KeySet{
+		HMACSecrets: []string{"secret"},
+	})
+
+	tok, _, err := staticjwt.HMACTokenGeneratorFunc("secret")(nil)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(withIncomingToken(context.Background(), tok), nil))
+
+	tok, _, err = staticjwt.HMACTokenGeneratorFunc("other secret")(nil)
+	require.NoError(t, err)
+	require.Error(t, p.Check(withIncomingToken(context.Background(), tok), nil))
+
+	require.Error(t, p.Check(context.Background(), nil))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// WithTracing - returns array of grpc.ServerOption that should be passed to grpc.NewServer to extract W3C trace context
+// from the incoming gRPC metadata
+func WithTracing() []grpc.ServerOption {
+	if IsEnabled() {
+		return []grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(
+				otelgrpc.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(
+				otelgrpc.StreamServerInterceptor()),
+		}
+	}
+	return []grpc.ServerOption{
+		grpc.EmptyServerOption{},
+	}
+}
+
+// WithTracingDial returns array of grpc.DialOption that should be passed to grpc.Dial to inject W3C trace context
+// into the outgoing gRPC metadata
+func WithTracingDial() []grpc.DialOption {
+	if IsEnabled() {
+		return []grpc.DialOption{
+			grpc.WithChainUnaryInterceptor(
+				otelgrpc.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(
+				otelgrpc.StreamClientInterceptor()),
+		}
+	}
+	return []grpc.DialOption{
+		grpc.EmptyDialOption{},
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opentelemetry provides a set of utilities for assisting with using OpenTelemetry tracing
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	telemetryEnv     = "TRACER_ENABLED"
+	telemetryDefault = true
+
+	collectorEndpointEnv     = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	collectorEndpointDefault = "localhost:4317"
+
+	instrumentationName = "github.com/networkservicemesh/sdk"
+)
+
+// IsEnabled returns true if OpenTelemetry tracing is enabled
+func IsEnabled() bool {
+	val, err := readEnvBool(telemetryEnv, telemetryDefault)
+	if err == nil {
+		return val
+	}
+	return telemetryDefault
+}
+
+func readEnvBool(env string, value bool) (bool, error) {
+	str := os.Getenv(env)
+	if str == "" {
+		return value, nil
+	}
+
+	return strconv.ParseBool(str)
+}
+
+// Tracer returns the SDK tracer from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+type emptyCloser struct {
+}
+
+func (*emptyCloser) Close() error {
+	// Ignore
+	return nil
+}
+
+type tracerProviderCloser struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+func (c *tracerProviderCloser) Close() error {
+	return c.tracerProvider.Shutdown(context.Background())
+}
+
+// Init - sets global OpenTelemetry tracer provider that samples 100% of traces and exports them with OTLP to the
+// collector, and global W3C trace context propagator. Returned io.Closer flushes and stops the exporter.
+func Init(ctx context.Context, service string, options ...Option) io.Closer {
+	if !IsEnabled() {
+		return &emptyCloser{}
+	}
+
+	o := &initOptions{
+		collectorEndpoint: os.Getenv(collectorEndpointEnv),
+	}
+	if o.collectorEndpoint == "" {
+		o.collectorEndpoint = collectorEndpointDefault
+	}
+	for _, opt := range options {
+		opt(o)
+	}
+
+	exporter := o.exporter
+	if exporter == nil {
+		var err error
+		exporter, err = otlp.NewExporter(ctx, otlpgrpc.NewDriver(
+			otlpgrpc.WithInsecure(),
+			otlpgrpc.WithEndpoint(o.collectorEndpoint),
+		))
+		if err != nil {
+			log.FromContext(ctx).Errorf("ERROR: cannot init OTLP exporter: %v\n", err)
+			return &emptyCloser{}
+		}
+	}
+
+	serviceName := service
+	if hostname, err := os.Hostname(); err == nil {
+		serviceName = fmt.Sprintf("%s@%s", service, hostname)
+	}
+
+	log.FromContext(ctx).Infof("Creating OpenTelemetry tracer provider for %s, collector: %s", serviceName, o.collectorEndpoint)
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return &tracerProviderCloser{
+		tracerProvider: tracerProvider,
+	}
+}
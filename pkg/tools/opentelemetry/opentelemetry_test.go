@@ -0,0 +1,162 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
+)
+
+// collector - OTLP collector stand-in keeping all exported spans
+type collector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	spans []*tracepb.Span
+	mu    sync.Mutex
+}
+
+func (c *collector) Export(_ context.Context, request *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, resourceSpans := range request.GetResourceSpans() {
+		for _, librarySpans := range resourceSpans.GetInstrumentationLibrarySpans() {
+			c.spans = append(c.spans, librarySpans.GetSpans()...)
+		}
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *collector) getSpans() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*tracepb.Span(nil), c.spans...)
+}
+
+type passThroughServer struct{}
+
+func (s *passThroughServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *passThroughServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func serve(ctx context.Context, t *testing.T, register func(server *grpc.Server), options ...grpc.ServerOption) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(options...)
+	register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestOpenTelemetry_PropagateTraceContext(t *testing.T) {
+	log.EnableTracing(true)
+	t.Cleanup(func() { log.EnableTracing(false) })
+
+	tracerProvider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c := new(collector)
+	collectorURL := serve(ctx, t, func(server *grpc.Server) {
+		coltracepb.RegisterTraceServiceServer(server, c)
+	})
+
+	closer := opentelemetry.Init(ctx, "test", opentelemetry.WithCollectorEndpoint(collectorURL))
+
+	nseURL := serve(ctx, t, func(server *grpc.Server) {
+		networkservice.RegisterNetworkServiceServer(server, chain.NewNetworkServiceServer(
+			new(passThroughServer),
+			new(passThroughServer),
+		))
+	}, opentelemetry.WithTracing()...)
+
+	cc, err := grpc.DialContext(ctx, nseURL, append(opentelemetry.WithTracingDial(), grpc.WithInsecure(), grpc.WithBlock())...)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	requestCtx, span := opentelemetry.Tracer().Start(ctx, "request")
+	_, err = networkservice.NewNetworkServiceClient(cc).Request(requestCtx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+	})
+	span.End()
+	require.NoError(t, err)
+
+	// Flush all spans to the collector
+	require.NoError(t, closer.Close())
+
+	traceID := span.SpanContext().TraceID()
+
+	var elementSpans int
+	spans := c.getSpans()
+	for _, s := range spans {
+		require.Equal(t, traceID[:], s.GetTraceId())
+		if strings.Contains(s.GetName(), "passThroughServer.Request") {
+			require.NotEmpty(t, s.GetParentSpanId())
+			elementSpans++
+		}
+	}
+	require.Equal(t, 2, elementSpans)
+	// request span, client and server gRPC spans and element spans
+	require.Len(t, spans, 5)
+}
+
+func TestOpenTelemetry_Disabled(t *testing.T) {
+	require.NoError(t, os.Setenv("TRACER_ENABLED", "false"))
+	defer func() { _ = os.Unsetenv("TRACER_ENABLED") }()
+
+	require.False(t, opentelemetry.IsEnabled())
+	require.NoError(t, opentelemetry.Init(context.Background(), "test").Close())
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type initOptions struct {
+	collectorEndpoint string
+	exporter          sdktrace.SpanExporter
+}
+
+// Option is an option for Init
+type Option func(o *initOptions)
+
+// WithCollectorEndpoint sets OTLP collector gRPC endpoint, default is taken from OTEL_EXPORTER_OTLP_ENDPOINT env or
+// localhost:4317
+func WithCollectorEndpoint(collectorEndpoint string) Option {
+	return func(o *initOptions) {
+		o.collectorEndpoint = collectorEndpoint
+	}
+}
+
+// WithExporter sets span exporter to use instead of the OTLP one
+func WithExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *initOptions) {
+		o.exporter = exporter
+	}
+}
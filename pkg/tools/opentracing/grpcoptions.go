@@ -17,63 +17,27 @@
 // limitations under the License.
 
 // Package opentracing provides a set of utilities to assist in working with opentracing
+//
+// Deprecated: opentracing is replaced with OpenTelemetry, use opentelemetry package instead. This package forwards to
+// opentelemetry and will be removed in the next release.
 package opentracing
 
 import (
-	"context"
-
-	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
-	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 
-	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 )
 
 // WithTracing - returns array of grpc.ServerOption that should be passed to grpc.Dial to enable opentracing
+//
+// Deprecated: use opentelemetry.WithTracing instead.
 func WithTracing() []grpc.ServerOption {
-	if jaeger.IsOpentracingEnabled() {
-		interceptor := func(
-			ctx context.Context,
-			req interface{},
-			info *grpc.UnaryServerInfo,
-			handler grpc.UnaryHandler,
-		) (resp interface{}, err error) {
-			return otgrpc.OpenTracingServerInterceptor(opentracing.GlobalTracer())(ctx, proto.Clone(req.(proto.Message)), info, handler)
-		}
-		return []grpc.ServerOption{
-			grpc.ChainUnaryInterceptor(
-				interceptor),
-			grpc.ChainStreamInterceptor(
-				otgrpc.OpenTracingStreamServerInterceptor(opentracing.GlobalTracer())),
-		}
-	}
-	return []grpc.ServerOption{
-		grpc.EmptyServerOption{},
-	}
+	return opentelemetry.WithTracing()
 }
 
 // WithTracingDial returns array of grpc.DialOption that should be passed to grpc.Dial to enable opentracing
+//
+// Deprecated: use opentelemetry.WithTracingDial instead.
 func WithTracingDial() []grpc.DialOption {
-	if jaeger.IsOpentracingEnabled() {
-		interceptor := func(
-			ctx context.Context,
-			method string,
-			req, reply interface{},
-			cc *grpc.ClientConn,
-			invoker grpc.UnaryInvoker,
-			opts ...grpc.CallOption,
-		) error {
-			return otgrpc.OpenTracingClientInterceptor(opentracing.GlobalTracer())(ctx, method, proto.Clone(req.(proto.Message)), reply, cc, invoker, opts...)
-		}
-		return []grpc.DialOption{
-			grpc.WithChainUnaryInterceptor(
-				interceptor),
-			grpc.WithChainStreamInterceptor(
-				otgrpc.OpenTracingStreamClientInterceptor(opentracing.GlobalTracer())),
-		}
-	}
-	return []grpc.DialOption{
-		grpc.EmptyDialOption{},
-	}
+	return opentelemetry.WithTracingDial()
 }
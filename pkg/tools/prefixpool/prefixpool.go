@@ -235,6 +235,39 @@ func (impl *PrefixPool) Extract(connectionID string, family networkservice.IpFam
 	return &net.IPNet{IP: src, Mask: ipNet.Mask}, &net.IPNet{IP: dst, Mask: ipNet.Mask}, requested, nil
 }
 
+// ExtractExtraPrefixes extracts only the requested prefixes for the given connection skipping the excluded prefixes
+func (impl *PrefixPool) ExtractExtraPrefixes(connectionID string, excludedPrefixes []string, requests ...*networkservice.ExtraPrefixRequest) (requested []string, err error) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+
+	/* Use a working copy for the available prefixes */
+	working := &PrefixPool{
+		prefixes: append([]string(nil), impl.prefixes...),
+	}
+	removed, err := working.ExcludePrefixes(excludedPrefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	requested, remaining, err := ExtractPrefixes(working.prefixes, requests...)
+	if err != nil {
+		return nil, err
+	}
+
+	/* Return the excluded prefixes back to the pool */
+	remaining, err = releasePrefixes(remaining, removed...)
+	if err != nil {
+		return nil, err
+	}
+
+	impl.prefixes = remaining
+
+	impl.connections[connectionID] = &connectionRecord{
+		prefixes: requested,
+	}
+	return requested, nil
+}
+
 // Release releases prefixes from the connection
 func (impl *PrefixPool) Release(connectionID string) error {
 	impl.mutex.Lock()
@@ -251,9 +284,11 @@ func (impl *PrefixPool) Release(connectionID string) error {
 		return err
 	}
 
-	remaining, err = releasePrefixes(remaining, conn.ipNet.String())
-	if err != nil {
-		return err
+	if conn.ipNet != nil {
+		remaining, err = releasePrefixes(remaining, conn.ipNet.String())
+		if err != nil {
+			return err
+		}
 	}
 
 	impl.prefixes = remaining
@@ -268,7 +303,10 @@ func (impl *PrefixPool) GetConnectionInformation(connectionID string) (ipNet str
 	if conn == nil {
 		return "", nil, errors.Errorf("No connection with id: %s is found", connectionID)
 	}
-	return conn.ipNet.String(), conn.prefixes, nil
+	if conn.ipNet != nil {
+		ipNet = conn.ipNet.String()
+	}
+	return ipNet, conn.prefixes, nil
 }
 
 // Intersect returns is there any intersection with existing prefixes
@@ -37,13 +37,14 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgrproxy"
+	"github.com/networkservicemesh/sdk/pkg/registry"
 	"github.com/networkservicemesh/sdk/pkg/registry/chains/client"
 	"github.com/networkservicemesh/sdk/pkg/registry/chains/memory"
 	"github.com/networkservicemesh/sdk/pkg/registry/chains/proxydns"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
-	"github.com/networkservicemesh/sdk/pkg/tools/opentracing"
+	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
@@ -58,10 +59,12 @@ type Builder struct {
 	supplyNSMgr            SupplyNSMgrFunc
 	supplyNSMgrProxy       SupplyNSMgrProxyFunc
 	supplyRegistry         SupplyRegistryFunc
+	supplyRegistryReplica  SupplyRegistryReplicaFunc
 	supplyRegistryProxy    SupplyRegistryProxyFunc
 	setupNode              SetupNodeFunc
 	generateTokenFunc      token.GeneratorFunc
 	registryExpiryDuration time.Duration
+	registryReplicasCount  int
 	ctx                    context.Context
 	t                      *testing.T
 
@@ -70,6 +73,15 @@ type Builder struct {
 	usedAddress    int
 }
 
+func supplyMemoryRegistryReplica(ctx context.Context, expiryDuration time.Duration, proxyRegistryURL *url.URL, peerURLs []*url.URL, options ...grpc.DialOption) registry.Registry {
+	return memory.NewServerWithOptions(ctx, expiryDuration, proxyRegistryURL,
+		memory.WithDialOptions(options...),
+		memory.WithPeers(peerURLs...),
+		// Sandbox uses insecure connections, so there are no peer SPIFFE IDs
+		memory.WithPeerAuthFunc(func(context.Context) bool { return true }),
+	)
+}
+
 // NewBuilder creates new SandboxBuilder
 func NewBuilder(t *testing.T) *Builder {
 	return &Builder{
@@ -79,11 +91,13 @@ func NewBuilder(t *testing.T) *Builder {
 		supplyNSMgr:            nsmgr.NewServer,
 		DNSDomainName:          "cluster.local",
 		supplyRegistry:         memory.NewServer,
+		supplyRegistryReplica:  supplyMemoryRegistryReplica,
 		supplyRegistryProxy:    proxydns.NewServer,
 		supplyNSMgrProxy:       nsmgrproxy.NewServer,
 		setupNode:              defaultSetupNode(t),
 		generateTokenFunc:      GenerateTestToken,
 		registryExpiryDuration: time.Minute,
+		registryReplicasCount:  1,
 		t:                      t,
 
 		useUnixSockets: false,
@@ -115,13 +129,25 @@ func (b *Builder) Build() *Domain {
 	} else {
 		domain.RegistryProxy = b.newRegistryProxy(ctx, domain.NSMgrProxy.URL)
 	}
-	if domain.RegistryProxy == nil {
-		domain.Registry = b.newRegistry(ctx, nil)
-	} else {
-		domain.Registry = b.newRegistry(ctx, domain.RegistryProxy.URL)
+	var registryProxyURL *url.URL
+	if domain.RegistryProxy != nil {
+		registryProxyURL = domain.RegistryProxy.URL
+	}
+	if b.registryReplicasCount > 1 {
+		domain.RegistryReplicas = b.newRegistryReplicas(ctx, registryProxyURL)
+	} else if entry := b.newRegistry(ctx, registryProxyURL); entry != nil {
+		domain.RegistryReplicas = []*RegistryEntry{entry}
+	}
+	if len(domain.RegistryReplicas) > 0 {
+		domain.Registry = domain.RegistryReplicas[0]
 	}
 	for i := 0; i < b.nodesCount; i++ {
-		domain.Nodes = append(domain.Nodes, b.newNode(ctx, domain.Registry.URL, b.nodesConfig[i]))
+		var registryURL *url.URL
+		if len(domain.RegistryReplicas) > 0 {
+			// Nodes are spread over the registry replicas
+			registryURL = domain.RegistryReplicas[i%len(domain.RegistryReplicas)].URL
+		}
+		domain.Nodes = append(domain.Nodes, b.newNode(ctx, registryURL, b.nodesConfig[i]))
 	}
 
 	domain.resources, b.resources = b.resources, nil
@@ -223,6 +249,22 @@ func (b *Builder) SetRegistrySupplier(f SupplyRegistryFunc) *Builder {
 	return b
 }
 
+// SetRegistryReplicaSupplier replaces default memory registry replica supplier to custom function
+func (b *Builder) SetRegistryReplicaSupplier(f SupplyRegistryReplicaFunc) *Builder {
+	b.supplyRegistryReplica = f
+	return b
+}
+
+// SetRegistryReplicasCount sets count of the registry replicas replicating to each other, nodes are spread over the
+// replicas. Default is 1 meaning a single registry without replication.
+func (b *Builder) SetRegistryReplicasCount(registryReplicasCount int) *Builder {
+	if registryReplicasCount < 1 {
+		panic("registryReplicasCount should be positive")
+	}
+	b.registryReplicasCount = registryReplicasCount
+	return b
+}
+
 // SetDNSDomainName sets DNS domain name for the building NSM domain
 func (b *Builder) SetDNSDomainName(name string) *Builder {
 	b.DNSDomainName = name
@@ -339,7 +381,7 @@ func (b *Builder) newNSMgr(ctx context.Context, address string, registryURL *url
 }
 
 func serve(ctx context.Context, u *url.URL, register func(server *grpc.Server)) {
-	server := grpc.NewServer(opentracing.WithTracing()...)
+	server := grpc.NewServer(opentelemetry.WithTracing()...)
 	register(server)
 	errCh := grpcutils.ListenAndServe(ctx, u, server)
 	go func() {
@@ -383,6 +425,34 @@ func (b *Builder) newRegistry(ctx context.Context, proxyRegistryURL *url.URL) *R
 	}
 }
 
+func (b *Builder) newRegistryReplicas(ctx context.Context, proxyRegistryURL *url.URL) []*RegistryEntry {
+	if b.supplyRegistryReplica == nil {
+		return nil
+	}
+
+	// All replicas URLs should be known before the start to set the peers
+	var serveURLs []*url.URL
+	for i := 0; i < b.registryReplicasCount; i++ {
+		serveURLs = append(serveURLs, b.reserveURL("reg"))
+	}
+
+	var entries []*RegistryEntry
+	for i, serveURL := range serveURLs {
+		var peerURLs []*url.URL
+		peerURLs = append(peerURLs, serveURLs[:i]...)
+		peerURLs = append(peerURLs, serveURLs[i+1:]...)
+
+		result := b.supplyRegistryReplica(ctx, b.registryExpiryDuration, proxyRegistryURL, peerURLs, DefaultDialOptions(b.generateTokenFunc)...)
+		serve(ctx, serveURL, result.Register)
+		log.FromContext(ctx).Infof("Registry replica %d listen on: %v", i, serveURL)
+		entries = append(entries, &RegistryEntry{
+			URL:      serveURL,
+			Registry: result,
+		})
+	}
+	return entries
+}
+
 func (b *Builder) newNode(ctx context.Context, registryURL *url.URL, nodeConfig *NodeConfig) *Node {
 	address := b.newAddress("nsmgr")
 	nsmgrEntry := b.newNSMgr(nodeConfig.NsmgrCtx, address, registryURL, nodeConfig.NsmgrGenerateTokenFunc)
@@ -407,7 +477,7 @@ func (b *Builder) SetupRegistryClients(ctx context.Context, node *Node) {
 
 	node.ForwarderRegistryClient = client.NewNetworkServiceEndpointRegistryInterposeClient(ctx, nsmgrCC)
 	node.EndpointRegistryClient = client.NewNetworkServiceEndpointRegistryClient(ctx, nsmgrCC)
-	node.NSRegistryClient = client.NewNetworkServiceRegistryClient(nsmgrCC)
+	node.NSRegistryClient = client.NewNetworkServiceRegistryRefreshClient(ctx, nsmgrCC)
 }
 
 // newAddress - will return a new public address, if unixSockets are used prefix will be used to make uniq files.
@@ -420,6 +490,20 @@ func (b *Builder) newAddress(prefix string) string {
 	return fmt.Sprintf("unix:%s/%s_%d.sock", b.sockPath, prefix, b.usedAddress)
 }
 
+// reserveURL - will return a new URL to listen on, it is needed when the URL should be known before the server start.
+func (b *Builder) reserveURL(prefix string) *url.URL {
+	address := b.newAddress(prefix)
+	if b.useUnixSockets {
+		return grpcutils.TargetToURL(address)
+	}
+
+	listener, err := net.Listen("tcp", address)
+	b.require.NoError(err)
+	serveURL := grpcutils.AddressToURL(listener.Addr())
+	b.require.NoError(listener.Close())
+	return serveURL
+}
+
 func defaultSetupNode(t *testing.T) SetupNodeFunc {
 	return func(ctx context.Context, node *Node, nodeConfig *NodeConfig) {
 		nseReg := &registryapi.NetworkServiceEndpoint{
@@ -41,6 +41,9 @@ type SupplyNSMgrFunc func(context.Context, token.GeneratorFunc, ...nsmgr.Option)
 // SupplyRegistryFunc supplies Registry
 type SupplyRegistryFunc func(ctx context.Context, expiryDuration time.Duration, proxyRegistryURL *url.URL, options ...grpc.DialOption) registry.Registry
 
+// SupplyRegistryReplicaFunc supplies Registry replica replicating to the peer replicas
+type SupplyRegistryReplicaFunc func(ctx context.Context, expiryDuration time.Duration, proxyRegistryURL *url.URL, peerURLs []*url.URL, options ...grpc.DialOption) registry.Registry
+
 // SupplyRegistryProxyFunc supplies registry proxy
 type SupplyRegistryProxyFunc func(ctx context.Context, dnsResolver dnsresolve.Resolver, handlingDNSDomain string, proxyNSMgrURL *url.URL, options ...grpc.DialOption) registry.Registry
 
@@ -67,14 +70,15 @@ type EndpointEntry struct {
 
 // Domain contains attached to domain nodes, registry
 type Domain struct {
-	Nodes         []*Node
-	NSMgrProxy    *EndpointEntry
-	Registry      *RegistryEntry
-	RegistryProxy *RegistryEntry
-	DNSResolver   dnsresolve.Resolver
-	Name          string
-	resources     []context.CancelFunc
-	domainTemp    string
+	Nodes            []*Node
+	NSMgrProxy       *EndpointEntry
+	Registry         *RegistryEntry
+	RegistryReplicas []*RegistryEntry
+	RegistryProxy    *RegistryEntry
+	DNSResolver      dnsresolve.Resolver
+	Name             string
+	resources        []context.CancelFunc
+	domainTemp       string
 }
 
 // NodeConfig keeps custom node configuration parameters
@@ -0,0 +1,46 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticjwt
+
+import "time"
+
+const defaultTokenTTL = 10 * time.Minute
+
+type options struct {
+	ttl     time.Duration
+	subject string
+}
+
+// Option is an option for the token generator
+type Option func(o *options)
+
+// WithTTL sets lifetime of the generated tokens, default is 10m
+func WithTTL(ttl time.Duration) Option {
+	if ttl <= 0 {
+		panic("ttl should be positive")
+	}
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithSubject sets subject of the generated tokens, default is empty
+func WithSubject(subject string) Option {
+	return func(o *options) {
+		o.subject = subject
+	}
+}
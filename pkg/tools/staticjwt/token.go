@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticjwt
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// HMACTokenGeneratorFunc - creates a token.GeneratorFunc that creates HS256 signed JWT tokens
+func HMACTokenGeneratorFunc(secret string, opts ...Option) token.GeneratorFunc {
+	return tokenGeneratorFunc(jwt.SigningMethodHS256, []byte(secret), opts...)
+}
+
+// ECDSATokenGeneratorFunc - creates a token.GeneratorFunc that creates ES256 signed JWT tokens, key should be on the
+// P-256 curve
+func ECDSATokenGeneratorFunc(key *ecdsa.PrivateKey, opts ...Option) token.GeneratorFunc {
+	return tokenGeneratorFunc(jwt.SigningMethodES256, key, opts...)
+}
+
+// ECDSAPrivateKeyFromFile - loads PEM encoded ECDSA private key from the file
+func ECDSAPrivateKeyFromFile(path string) (*ecdsa.PrivateKey, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read private key from %s", path)
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse private key from %s", path)
+	}
+	return key, nil
+}
+
+func tokenGeneratorFunc(method jwt.SigningMethod, key interface{}, opts ...Option) token.GeneratorFunc {
+	o := &options{
+		ttl: defaultTokenTTL,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(_ credentials.AuthInfo) (string, time.Time, error) {
+		now := time.Now()
+		expireTime := now.Add(o.ttl)
+		claims := jwt.StandardClaims{
+			Subject:   o.subject,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expireTime.Unix(),
+		}
+		tok, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			return "", time.Time{}, errors.Wrap(err, "Error creating Token")
+		}
+		return tok, expireTime, nil
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticjwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/staticjwt"
+)
+
+func TestHMACTokenGeneratorFunc(t *testing.T) {
+	tok, expireTime, err := staticjwt.HMACTokenGeneratorFunc("secret",
+		staticjwt.WithTTL(time.Hour),
+		staticjwt.WithSubject("nsc"))(nil)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Hour), expireTime, time.Second)
+
+	claims := new(jwt.StandardClaims)
+	_, err = jwt.ParseWithClaims(tok, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte("secret"), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nsc", claims.Subject)
+	require.Equal(t, expireTime.Unix(), claims.ExpiresAt)
+}
+
+func TestECDSATokenGeneratorFunc(t *testing.T) {
+	dir, err := ioutil.TempDir("", "staticjwt")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	b, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}), 0600)
+	require.NoError(t, err)
+
+	loadedKey, err := staticjwt.ECDSAPrivateKeyFromFile(keyPath)
+	require.NoError(t, err)
+
+	tok, _, err := staticjwt.ECDSATokenGeneratorFunc(loadedKey, staticjwt.WithSubject("nsc"))(nil)
+	require.NoError(t, err)
+
+	claims := new(jwt.StandardClaims)
+	_, err = jwt.ParseWithClaims(tok, claims, func(*jwt.Token) (interface{}, error) {
+		return key.Public(), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nsc", claims.Subject)
+}
@@ -25,10 +25,15 @@ import (
 
 // GetFuncName - returns the function name from the passed value (interface) and method name
 func GetFuncName(value interface{}, methodName string) string {
+	return fmt.Sprintf("%s.%s", GetTypeName(value), methodName)
+}
+
+// GetTypeName - returns the type name with the package path from the passed value (interface)
+func GetTypeName(value interface{}) string {
 	v := reflect.ValueOf(value)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 	pkgPath := strings.TrimPrefix(v.Type().PkgPath(), "github.com/networkservicemesh/")
-	return fmt.Sprintf("%s/%s.%s", pkgPath, v.Type().Name(), methodName)
+	return fmt.Sprintf("%s/%s", pkgPath, v.Type().Name())
 }